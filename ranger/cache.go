@@ -0,0 +1,103 @@
+package ranger
+
+import (
+	"iter"
+	"sync"
+)
+
+// Cached realizes the elements of a sequence at most once, on demand, and
+// replays them to any number of (possibly concurrent) iterations.
+type Cached[V any] struct {
+	pulling sync.Mutex   // serializes use of the source (seq, next and stop)
+	mutex   sync.RWMutex // guards values and done, so replays never wait on the source
+	seq     iter.Seq[V]
+	next    func() (V, bool)
+	stop    func()
+	values  []V
+	done    bool
+}
+
+// Cache wraps seq, which is not started until an element is first requested
+// (so a Cached that is never iterated holds no resources and needs no Close).
+func Cache[V any](seq iter.Seq[V]) *Cached[V] {
+	return &Cached[V]{seq: seq}
+}
+
+// All replays the realized elements and then realizes more as needed.
+func (this *Cached[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := 0; ; i++ {
+			v, ok := this.At(i)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// At realizes elements up to and including index i, reporting false when the
+// underlying sequence ends (or the cache is closed) before reaching it. Elements
+// already realized are returned without waiting for another caller's realization.
+func (this *Cached[V]) At(i int) (result V, ok bool) {
+	if i < 0 {
+		return result, false
+	}
+	if v, ok, known := this.lookup(i); known {
+		return v, ok
+	}
+	this.pulling.Lock()
+	defer this.pulling.Unlock()
+	for {
+		if v, ok, known := this.lookup(i); known {
+			return v, ok
+		}
+		if this.next == nil {
+			this.next, this.stop = iter.Pull(this.seq)
+		}
+		v, ok := this.next()
+		if !ok {
+			this.close()
+			continue
+		}
+		this.mutex.Lock()
+		this.values = append(this.values, v)
+		this.mutex.Unlock()
+	}
+}
+
+// Len reports how many elements have been realized so far.
+func (this *Cached[V]) Len() int {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return len(this.values)
+}
+
+// Close stops the underlying sequence, which is only necessary when it was not
+// iterated to completion. Elements realized before Close are still replayed.
+func (this *Cached[V]) Close() {
+	this.pulling.Lock()
+	defer this.pulling.Unlock()
+	this.close()
+}
+
+// lookup returns the element at index i if it has been realized, with known
+// reporting whether the answer is final (ie. the element exists or never will).
+func (this *Cached[V]) lookup(i int) (result V, ok, known bool) {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	if i < len(this.values) {
+		return this.values[i], true, true
+	}
+	return result, false, this.done
+}
+
+// close marks the cache done and stops the source. Callers hold the pulling lock.
+func (this *Cached[V]) close() {
+	this.mutex.Lock()
+	wasDone := this.done
+	this.done = true
+	this.mutex.Unlock()
+	if !wasDone && this.stop != nil {
+		this.stop()
+	}
+}
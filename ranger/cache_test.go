@@ -0,0 +1,115 @@
+package ranger
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+)
+
+func TestCache(t *testing.T) {
+	var calls atomic.Int32
+	square := func(n int) int { calls.Add(1); return n * n }
+	cached := Cache(Map(square, once(Range(0, 5))))
+	should.So(t, calls.Load(), should.Equal, int32(0))
+	should.So(t, Slice(Take(2, cached.All())), should.Equal, []int{0, 1})
+	should.So(t, cached.Len(), should.Equal, 2)
+	should.So(t, Slice(cached.All()), should.Equal, []int{0, 1, 4, 9, 16})
+	should.So(t, Slice(cached.All()), should.Equal, []int{0, 1, 4, 9, 16})
+	should.So(t, calls.Load(), should.Equal, int32(5))
+
+	v, ok := cached.At(3)
+	should.So(t, v, should.Equal, 9)
+	should.So(t, ok, should.BeTrue)
+	_, ok = cached.At(5)
+	should.So(t, ok, should.BeFalse)
+	_, ok = cached.At(-1)
+	should.So(t, ok, should.BeFalse)
+}
+func TestCacheInfinite(t *testing.T) {
+	cached := Cache(Iterate(func(n int) int { return n * 2 }, 1))
+	defer cached.Close()
+	v, ok := cached.At(9)
+	should.So(t, v, should.Equal, 1024)
+	should.So(t, ok, should.BeTrue)
+	should.So(t, cached.Len(), should.Equal, 10)
+	should.So(t, Slice(Take(3, cached.All())), should.Equal, []int{2, 4, 8})
+	should.So(t, Slice(Take(12, cached.All()))[11], should.Equal, 4096)
+}
+func TestCacheClose(t *testing.T) {
+	cached := Cache(RangeOpen(0, 1))
+	should.So(t, Slice(Take(3, cached.All())), should.Equal, []int{0, 1, 2})
+	cached.Close()
+	cached.Close()
+	should.So(t, Slice(cached.All()), should.Equal, []int{0, 1, 2})
+}
+func TestCacheLazy(t *testing.T) {
+	var started atomic.Bool
+	source := func(yield func(int) bool) {
+		started.Store(true)
+		for n := 0; yield(n); n++ {
+		}
+	}
+	unused := Cache(iter.Seq[int](source))
+	should.So(t, unused.Len(), should.Equal, 0)
+	should.So(t, started.Load(), should.BeFalse)
+
+	closed := Cache(iter.Seq[int](source))
+	closed.Close()
+	should.So(t, Slice(closed.All()), should.BeEmpty)
+	should.So(t, started.Load(), should.BeFalse)
+
+	used := Cache(iter.Seq[int](source))
+	defer used.Close()
+	v, ok := used.At(2)
+	should.So(t, v, should.Equal, 2)
+	should.So(t, ok, should.BeTrue)
+	should.So(t, started.Load(), should.BeTrue)
+}
+func TestCacheConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	cached := Cache(Map(func(n int) int { calls.Add(1); return n }, Range(0, 100)))
+	var wg sync.WaitGroup
+	results := make([][]int, 8)
+	for x := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[x] = Slice(cached.All())
+		}()
+	}
+	wg.Wait()
+	for _, result := range results {
+		should.So(t, result, should.Equal, Slice(Range(0, 100)))
+	}
+	should.So(t, calls.Load(), should.Equal, int32(100))
+}
+func TestCacheReplayDoesNotWaitForRealization(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	cached := Cache(iter.Seq[int](func(yield func(int) bool) {
+		if !yield(0) {
+			return
+		}
+		close(entered)
+		<-release
+		yield(1)
+	}))
+	defer cached.Close()
+	should.So(t, cached.Len(), should.Equal, 0)
+	_, _ = cached.At(0)
+
+	realized := make(chan int)
+	go func() {
+		v, _ := cached.At(1)
+		realized <- v
+	}()
+	<-entered
+	var v int
+	should.So(t, returnsPromptly(func() { v, _ = cached.At(0) }), should.BeTrue)
+	should.So(t, v, should.Equal, 0)
+	should.So(t, cached.Len(), should.Equal, 1)
+	close(release)
+	should.So(t, <-realized, should.Equal, 1)
+}
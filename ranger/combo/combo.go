@@ -0,0 +1,193 @@
+// Package combo lazily enumerates permutations, combinations, cartesian products and
+// power sets. Elements are chosen by position, so output is in lexicographic order of
+// the input positions (which is sorted order when the input itself is sorted), and
+// duplicate input values are treated as distinct.
+//
+// Each yielded slice is freshly allocated. The *Shared variants instead yield the same
+// slice every time, overwriting it on each iteration, which avoids an allocation per
+// result but requires callers to copy anything they wish to retain.
+package combo
+
+import (
+	"iter"
+	"slices"
+)
+
+func CartesianProduct[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return fresh(CartesianProductShared(seqs...))
+}
+func CartesianProductShared[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pools := make([][]V, len(seqs))
+		for x, seq := range seqs {
+			pools[x] = slices.Collect(seq)
+			if len(pools[x]) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(pools))
+		buffer := make([]V, len(pools))
+		for {
+			for x, i := range indices {
+				buffer[x] = pools[x][i]
+			}
+			if !yield(buffer) {
+				return
+			}
+			x := len(indices) - 1
+			for ; x >= 0; x-- {
+				indices[x]++
+				if indices[x] < len(pools[x]) {
+					break
+				}
+				indices[x] = 0
+			}
+			if x < 0 {
+				return
+			}
+		}
+	}
+}
+func Combinations[V any](k int, seq iter.Seq[V]) iter.Seq[[]V] {
+	return fresh(CombinationsShared(k, seq))
+}
+func CombinationsShared[V any](k int, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pool := slices.Collect(seq)
+		n := len(pool)
+		if k < 0 || k > n {
+			return
+		}
+		indices := make([]int, k)
+		for x := range indices {
+			indices[x] = x
+		}
+		buffer := make([]V, k)
+		for {
+			if !yield(fill(buffer, pool, indices)) {
+				return
+			}
+			x := k - 1
+			for x >= 0 && indices[x] == x+n-k {
+				x--
+			}
+			if x < 0 {
+				return
+			}
+			indices[x]++
+			for y := x + 1; y < k; y++ {
+				indices[y] = indices[y-1] + 1
+			}
+		}
+	}
+}
+func CombinationsWithReplacement[V any](k int, seq iter.Seq[V]) iter.Seq[[]V] {
+	return fresh(CombinationsWithReplacementShared(k, seq))
+}
+func CombinationsWithReplacementShared[V any](k int, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pool := slices.Collect(seq)
+		n := len(pool)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+		indices := make([]int, k)
+		buffer := make([]V, k)
+		for {
+			if !yield(fill(buffer, pool, indices)) {
+				return
+			}
+			x := k - 1
+			for x >= 0 && indices[x] == n-1 {
+				x--
+			}
+			if x < 0 {
+				return
+			}
+			indices[x]++
+			for y := x + 1; y < k; y++ {
+				indices[y] = indices[x]
+			}
+		}
+	}
+}
+func Permutations[V any](seq iter.Seq[V]) iter.Seq[[]V] {
+	return fresh(PermutationsShared(seq))
+}
+func PermutationsShared[V any](seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pool := slices.Collect(seq)
+		n := len(pool)
+		indices := make([]int, n)
+		for x := range indices {
+			indices[x] = x
+		}
+		buffer := make([]V, n)
+		for {
+			if !yield(fill(buffer, pool, indices)) {
+				return
+			}
+			i := n - 2
+			for i >= 0 && indices[i] > indices[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := n - 1
+			for indices[j] < indices[i] {
+				j--
+			}
+			indices[i], indices[j] = indices[j], indices[i]
+			slices.Reverse(indices[i+1:])
+		}
+	}
+}
+
+// PowerSet yields every subset, starting with the empty set, in lexicographic
+// order of positions: [], [a], [a b], [a b c], [a c], [b], [b c], [c].
+func PowerSet[V any](seq iter.Seq[V]) iter.Seq[[]V] {
+	return fresh(PowerSetShared(seq))
+}
+func PowerSetShared[V any](seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pool := slices.Collect(seq)
+		n := len(pool)
+		buffer := make([]V, 0, n)
+		indices := make([]int, 0, n)
+		if !yield(buffer) || n == 0 {
+			return
+		}
+		indices = append(indices, 0)
+		for {
+			if !yield(fill(buffer[:len(indices)], pool, indices)) {
+				return
+			}
+			if last := indices[len(indices)-1]; last+1 < n {
+				indices = append(indices, last+1)
+				continue
+			}
+			indices = indices[:len(indices)-1]
+			if len(indices) == 0 {
+				return
+			}
+			indices[len(indices)-1]++
+		}
+	}
+}
+
+func fill[V any](buffer, pool []V, indices []int) []V {
+	for x, i := range indices {
+		buffer[x] = pool[i]
+	}
+	return buffer
+}
+func fresh[V any](seq iter.Seq[[]V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		for s := range seq {
+			if !yield(slices.Clone(s)) {
+				return
+			}
+		}
+	}
+}
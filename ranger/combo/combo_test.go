@@ -0,0 +1,71 @@
+package combo_test
+
+import (
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/combo"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+)
+
+var (
+	abc  = ranger.Variadic("a", "b", "c")
+	none = ranger.Variadic[string]()
+)
+
+func TestPermutations(t *testing.T) {
+	should.So(t, ranger.Slice(combo.Permutations(abc)), should.Equal, [][]string{
+		{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"}, {"b", "c", "a"}, {"c", "a", "b"}, {"c", "b", "a"},
+	})
+	should.So(t, ranger.Slice(combo.Permutations(none)), should.Equal, [][]string{{}})
+	should.So(t, ranger.Count(combo.Permutations(ranger.Range(0, 6))), should.Equal, 720)
+	should.So(t, ranger.Slice(ranger.Take(2, combo.Permutations(abc))), should.Equal, [][]string{{"a", "b", "c"}, {"a", "c", "b"}})
+}
+func TestCombinations(t *testing.T) {
+	should.So(t, ranger.Slice(combo.Combinations(2, abc)), should.Equal, [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}})
+	should.So(t, ranger.Slice(combo.Combinations(3, abc)), should.Equal, [][]string{{"a", "b", "c"}})
+	should.So(t, ranger.Slice(combo.Combinations(0, abc)), should.Equal, [][]string{{}})
+	should.So(t, ranger.Slice(combo.Combinations(4, abc)), should.BeEmpty)
+	should.So(t, ranger.Slice(combo.Combinations(-1, abc)), should.BeEmpty)
+	should.So(t, ranger.Count(combo.Combinations(3, ranger.Range(0, 10))), should.Equal, 120)
+}
+func TestCombinationsWithReplacement(t *testing.T) {
+	should.So(t, ranger.Slice(combo.CombinationsWithReplacement(2, abc)), should.Equal, [][]string{
+		{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"},
+	})
+	should.So(t, ranger.Slice(combo.CombinationsWithReplacement(0, none)), should.Equal, [][]string{{}})
+	should.So(t, ranger.Slice(combo.CombinationsWithReplacement(1, none)), should.BeEmpty)
+	should.So(t, ranger.Slice(combo.CombinationsWithReplacement(-1, abc)), should.BeEmpty)
+}
+func TestCartesianProduct(t *testing.T) {
+	should.So(t, ranger.Slice(combo.CartesianProduct(ranger.Variadic("a", "b"), ranger.Variadic("x", "y"), ranger.Variadic("1"))), should.Equal, [][]string{
+		{"a", "x", "1"}, {"a", "y", "1"}, {"b", "x", "1"}, {"b", "y", "1"},
+	})
+	should.So(t, ranger.Slice(combo.CartesianProduct(abc, none)), should.BeEmpty)
+	should.So(t, ranger.Slice(combo.CartesianProduct[string]()), should.Equal, [][]string{{}})
+	should.So(t, ranger.Slice(ranger.Take(2, combo.CartesianProduct(abc, abc))), should.Equal, [][]string{{"a", "a"}, {"a", "b"}})
+}
+func TestPowerSet(t *testing.T) {
+	should.So(t, ranger.Slice(combo.PowerSet(abc)), should.Equal, [][]string{
+		{}, {"a"}, {"a", "b"}, {"a", "b", "c"}, {"a", "c"}, {"b"}, {"b", "c"}, {"c"},
+	})
+	should.So(t, ranger.Slice(combo.PowerSet(none)), should.Equal, [][]string{{}})
+	should.So(t, ranger.Count(combo.PowerSet(ranger.Range(0, 10))), should.Equal, 1024)
+	should.So(t, ranger.Slice(ranger.Take(2, combo.PowerSet(abc))), should.Equal, [][]string{{}, {"a"}})
+}
+func TestShared(t *testing.T) {
+	var firsts [][]string
+	for permutation := range combo.PermutationsShared(abc) {
+		firsts = append(firsts, permutation)
+	}
+	should.So(t, firsts[0], should.Equal, []string{"c", "b", "a"})
+
+	fresh := ranger.Slice(combo.Combinations(2, abc))
+	fresh[0][0] = "z"
+	should.So(t, fresh[1], should.Equal, []string{"a", "c"})
+
+	should.So(t, ranger.Count(combo.CombinationsShared(2, abc)), should.Equal, 3)
+	should.So(t, ranger.Count(combo.CombinationsWithReplacementShared(2, abc)), should.Equal, 6)
+	should.So(t, ranger.Count(combo.CartesianProductShared(abc, abc)), should.Equal, 9)
+	should.So(t, ranger.Count(combo.PowerSetShared(abc)), should.Equal, 8)
+}
@@ -3,11 +3,14 @@ package examples
 import (
 	"iter"
 	"strconv"
+	"strings"
 	"testing"
 	"unicode"
 
 	. "github.com/mdw-go/funcy/ranger"
 	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/text"
+	"github.com/mdw-go/funcy/ranger/try"
 )
 
 func TestAdventOfCode2023Day1Part1(t *testing.T) {
@@ -18,6 +21,11 @@ func TestAdventOfCode2023Day1Part1(t *testing.T) {
 	should.So(t, Calibrate("treb7uchet"), should.Equal, 77)
 	should.So(t, CalibrateAll(Variadic("1abc2", "pqr3stu8vwx", "a1b2c3d4e5f", "treb7uchet")),
 		should.Equal, 142)
+
+	input := strings.NewReader("1abc2\npqr3stu8vwx\na1b2c3d4e5f\ntreb7uchet\n")
+	lines, err := try.CollectErr(text.Lines(input))
+	should.So(t, err, should.BeNil)
+	should.So(t, CalibrateAll(Iterator(lines)), should.Equal, 142)
 }
 func CalibrateAll(lines iter.Seq[string]) int {
 	return Sum(Map(Calibrate, lines))
@@ -18,3 +18,15 @@ func TestThreadingMacroExample(t *testing.T) {
 		t.Fatalf("got %d, want %d", result, 1140)
 	}
 }
+
+// The same pipeline, read top to bottom with a Stream.
+func TestThreadingMacroStreamExample(t *testing.T) {
+	result := NewStream(RangeOpen(0, 1)).
+		Map(op.Square).
+		Filter(is.Even).
+		Take(10).
+		Reduce(op.Add, 0)
+	if result != 1140 {
+		t.Fatalf("got %d, want %d", result, 1140)
+	}
+}
@@ -0,0 +1,184 @@
+package ranger
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/mdw-go/funcy/ranger/is"
+)
+
+// The hash joins below materialize (index) the right sequence and stream the left,
+// yielding matches in left order and, for duplicate keys, every combination of the
+// matching elements (right elements in their original order). The Merge* joins
+// require both sequences to be sorted ascending by key and buffer only the run of
+// right elements sharing the current key; SortedMergeJoin is the inner merge join
+// for sequences ordered by an arbitrary comparator. Unmatched elements of an outer
+// join are paired with nil.
+
+func AntiJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		keys := make(map[K]struct{})
+		for b := range right {
+			keys[rightKey(b)] = struct{}{}
+		}
+		for a := range left {
+			if _, ok := keys[leftKey(a)]; !ok && !yield(a) {
+				return
+			}
+		}
+	}
+}
+func FullOuterJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[*A, *B]] {
+	return func(yield func(Pair[*A, *B]) bool) {
+		hashJoin(leftKey, rightKey, left, right, true, true, outer(yield))
+	}
+}
+func InnerJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		hashJoin(leftKey, rightKey, left, right, false, false, inner(yield))
+	}
+}
+func LeftJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[A, *B]] {
+	return func(yield func(Pair[A, *B]) bool) {
+		hashJoin(leftKey, rightKey, left, right, true, false, leftOuter(yield))
+	}
+}
+func MergeFullOuterJoin[K is.Comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[*A, *B]] {
+	return func(yield func(Pair[*A, *B]) bool) {
+		mergeJoin(compareKeys(leftKey, rightKey), left, right, true, true, outer(yield))
+	}
+}
+func MergeInnerJoin[K is.Comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		mergeJoin(compareKeys(leftKey, rightKey), left, right, false, false, inner(yield))
+	}
+}
+func MergeLeftJoin[K is.Comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[A, *B]] {
+	return func(yield func(Pair[A, *B]) bool) {
+		mergeJoin(compareKeys(leftKey, rightKey), left, right, true, false, leftOuter(yield))
+	}
+}
+func SemiJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		keys := make(map[K]struct{})
+		for b := range right {
+			keys[rightKey(b)] = struct{}{}
+		}
+		for a := range left {
+			if _, ok := keys[leftKey(a)]; ok && !yield(a) {
+				return
+			}
+		}
+	}
+}
+func SortedMergeJoin[A, B any](compare func(A, B) int, left iter.Seq[A], right iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		mergeJoin(compare, left, right, false, false, inner(yield))
+	}
+}
+
+// joined receives each row of a join; hasA/hasB are false for the missing side of an outer row.
+type joined[A, B any] func(a A, hasA bool, b B, hasB bool) bool
+
+func hashJoin[K comparable, A, B any](leftKey func(A) K, rightKey func(B) K, left iter.Seq[A], right iter.Seq[B], keepLeft, keepRight bool, yield joined[A, B]) {
+	var rights []B
+	var zeroA A
+	var zeroB B
+	index := make(map[K][]int)
+	for b := range right {
+		key := rightKey(b)
+		index[key] = append(index[key], len(rights))
+		rights = append(rights, b)
+	}
+	matched := make(map[K]struct{})
+	for a := range left {
+		key := leftKey(a)
+		positions, ok := index[key]
+		if !ok {
+			if keepLeft && !yield(a, true, zeroB, false) {
+				return
+			}
+			continue
+		}
+		if keepRight {
+			matched[key] = struct{}{}
+		}
+		for _, position := range positions {
+			if !yield(a, true, rights[position], true) {
+				return
+			}
+		}
+	}
+	if !keepRight {
+		return
+	}
+	for _, b := range rights {
+		if _, ok := matched[rightKey(b)]; !ok && !yield(zeroA, false, b, true) {
+			return
+		}
+	}
+}
+func mergeJoin[A, B any](compare func(A, B) int, left iter.Seq[A], right iter.Seq[B], keepLeft, keepRight bool, yield joined[A, B]) {
+	nextA, stopA := iter.Pull(left)
+	defer stopA()
+	nextB, stopB := iter.Pull(right)
+	defer stopB()
+	var zeroA A
+	var zeroB B
+	a, okA := nextA()
+	b, okB := nextB()
+	for okA || okB {
+		c := 0
+		if okA && okB {
+			c = compare(a, b)
+		}
+		switch {
+		case okA && (!okB || c < 0):
+			if keepLeft && !yield(a, true, zeroB, false) {
+				return
+			}
+			a, okA = nextA()
+		case okB && (!okA || c > 0):
+			if keepRight && !yield(zeroA, false, b, true) {
+				return
+			}
+			b, okB = nextB()
+		default:
+			group := []B{b}
+			for b, okB = nextB(); okB && compare(a, b) == 0; b, okB = nextB() {
+				group = append(group, b)
+			}
+			for ; okA && compare(a, group[0]) == 0; a, okA = nextA() {
+				for _, g := range group {
+					if !yield(a, true, g, true) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+func compareKeys[K is.Comparable, A, B any](leftKey func(A) K, rightKey func(B) K) func(A, B) int {
+	return func(a A, b B) int { return cmp.Compare(leftKey(a), rightKey(b)) }
+}
+func inner[A, B any](yield func(Pair[A, B]) bool) joined[A, B] {
+	return func(a A, _ bool, b B, _ bool) bool {
+		return yield(Pair[A, B]{A: a, B: b})
+	}
+}
+func leftOuter[A, B any](yield func(Pair[A, *B]) bool) joined[A, B] {
+	return func(a A, _ bool, b B, hasB bool) bool {
+		return yield(Pair[A, *B]{A: a, B: pointer(b, hasB)})
+	}
+}
+func outer[A, B any](yield func(Pair[*A, *B]) bool) joined[A, B] {
+	return func(a A, hasA bool, b B, hasB bool) bool {
+		return yield(Pair[*A, *B]{A: pointer(a, hasA), B: pointer(b, hasB)})
+	}
+}
+func pointer[V any](v V, ok bool) *V {
+	if !ok {
+		return nil
+	}
+	return &v
+}
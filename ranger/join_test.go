@@ -0,0 +1,82 @@
+package ranger
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+)
+
+type (
+	joinUser struct {
+		ID   int
+		Name string
+	}
+	joinOrder struct {
+		UserID int
+		Item   string
+	}
+)
+
+var (
+	joinUsers  = []joinUser{{1, "ann"}, {2, "bob"}, {3, "cat"}, {3, "cy"}}
+	joinOrders = []joinOrder{{1, "pen"}, {3, "ink"}, {3, "pad"}, {4, "cup"}}
+	userID     = func(u joinUser) int { return u.ID }
+	orderUser  = func(o joinOrder) int { return o.UserID }
+)
+
+func describeJoin[A, B any](seq iter.Seq[Pair[A, B]]) (result []string) {
+	deref := func(v any) any {
+		switch v := v.(type) {
+		case *joinUser:
+			if v == nil {
+				return nil
+			}
+			return *v
+		case *joinOrder:
+			if v == nil {
+				return nil
+			}
+			return *v
+		}
+		return v
+	}
+	for pair := range seq {
+		result = append(result, fmt.Sprintf("%v|%v", deref(pair.A), deref(pair.B)))
+	}
+	return result
+}
+
+func TestInnerJoin(t *testing.T) {
+	expected := []string{"{1 ann}|{1 pen}", "{3 cat}|{3 ink}", "{3 cat}|{3 pad}", "{3 cy}|{3 ink}", "{3 cy}|{3 pad}"}
+	should.So(t, describeJoin(InnerJoin(userID, orderUser, once(Iterator(joinUsers)), once(Iterator(joinOrders)))), should.Equal, expected)
+	should.So(t, describeJoin(MergeInnerJoin(userID, orderUser, once(Iterator(joinUsers)), once(Iterator(joinOrders)))), should.Equal, expected)
+	byUser := func(u joinUser, o joinOrder) int { return u.ID - o.UserID }
+	should.So(t, describeJoin(SortedMergeJoin(byUser, once(Iterator(joinUsers)), once(Iterator(joinOrders)))), should.Equal, expected)
+	should.So(t, describeJoin(Take(2, InnerJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders)))), should.Equal, expected[:2])
+	should.So(t, describeJoin(Take(2, MergeInnerJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders)))), should.Equal, expected[:2])
+}
+func TestLeftJoin(t *testing.T) {
+	expected := []string{"{1 ann}|{1 pen}", "{2 bob}|<nil>", "{3 cat}|{3 ink}", "{3 cat}|{3 pad}", "{3 cy}|{3 ink}", "{3 cy}|{3 pad}"}
+	should.So(t, describeJoin(LeftJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders))), should.Equal, expected)
+	should.So(t, describeJoin(MergeLeftJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders))), should.Equal, expected)
+}
+func TestFullOuterJoin(t *testing.T) {
+	expected := []string{"{1 ann}|{1 pen}", "{2 bob}|<nil>", "{3 cat}|{3 ink}", "{3 cat}|{3 pad}", "{3 cy}|{3 ink}", "{3 cy}|{3 pad}", "<nil>|{4 cup}"}
+	should.So(t, describeJoin(FullOuterJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders))), should.Equal, expected)
+	should.So(t, describeJoin(MergeFullOuterJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders))), should.Equal, expected)
+	should.So(t, describeJoin(MergeFullOuterJoin(orderUser, userID, Iterator(joinOrders), Iterator(joinUsers))), should.Equal, []string{
+		"{1 pen}|{1 ann}", "<nil>|{2 bob}", "{3 ink}|{3 cat}", "{3 ink}|{3 cy}", "{3 pad}|{3 cat}", "{3 pad}|{3 cy}", "{4 cup}|<nil>",
+	})
+	should.So(t, describeJoin(Take(1, FullOuterJoin(userID, orderUser, Iterator(joinUsers[1:2]), Iterator(joinOrders)))), should.Equal, []string{"{2 bob}|<nil>"})
+}
+func TestSemiJoin(t *testing.T) {
+	should.So(t, Slice(SemiJoin(userID, orderUser, once(Iterator(joinUsers)), once(Iterator(joinOrders)))), should.Equal, []joinUser{{1, "ann"}, {3, "cat"}, {3, "cy"}})
+	should.So(t, Slice(Take(1, SemiJoin(userID, orderUser, Iterator(joinUsers), Iterator(joinOrders)))), should.Equal, []joinUser{{1, "ann"}})
+}
+func TestAntiJoin(t *testing.T) {
+	should.So(t, Slice(AntiJoin(userID, orderUser, once(Iterator(joinUsers)), once(Iterator(joinOrders)))), should.Equal, []joinUser{{2, "bob"}})
+	should.So(t, Slice(AntiJoin(orderUser, userID, Iterator(joinOrders), Iterator(joinUsers))), should.Equal, []joinOrder{{4, "cup"}})
+	should.So(t, Slice(Take(0, AntiJoin(orderUser, userID, Iterator(joinOrders), Iterator(joinUsers)))), should.Equal, []joinOrder(nil))
+}
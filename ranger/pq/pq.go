@@ -0,0 +1,132 @@
+// Package pq implements a generic priority queue on top of container/heap.
+package pq
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// PriorityQueue orders its elements according to less, such that Pop and Peek
+// return the element for which less reports true against every other element
+// (ie. the minimum, when less is a conventional less-than).
+type PriorityQueue[T any] struct {
+	items *items[T]
+}
+
+// Handle refers to an element pushed onto a PriorityQueue, allowing that element's
+// priority to be changed (Update/Fix) or the element removed after the fact. A handle
+// becomes stale once its element leaves the queue (via Pop, Remove or Drain).
+type Handle[T any] struct {
+	value T
+	index int
+}
+
+func (this *Handle[T]) Value() T { return this.value }
+
+func New[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: &items[T]{less: less}}
+}
+
+func (this *PriorityQueue[T]) Len() int { return this.items.Len() }
+
+// Push adds t to the queue and returns a handle to it.
+func (this *PriorityQueue[T]) Push(t T) *Handle[T] {
+	handle := &Handle[T]{value: t}
+	heap.Push(this.items, handle)
+	return handle
+}
+
+// Pop removes and returns the highest priority element.
+func (this *PriorityQueue[T]) Pop() (result T, ok bool) {
+	if this.Len() == 0 {
+		return result, false
+	}
+	return heap.Pop(this.items).(*Handle[T]).value, true
+}
+
+// Peek returns (without removing) the highest priority element.
+func (this *PriorityQueue[T]) Peek() (result T, ok bool) {
+	if this.Len() == 0 {
+		return result, false
+	}
+	return this.items.handles[0].value, true
+}
+
+// Update replaces the element referred to by handle with t and restores heap order,
+// reporting false (and doing nothing) if the handle is stale.
+func (this *PriorityQueue[T]) Update(handle *Handle[T], t T) bool {
+	if !this.contains(handle) {
+		return false
+	}
+	handle.value = t
+	heap.Fix(this.items, handle.index)
+	return true
+}
+
+// Fix restores heap order after the priority of the element referred to by handle
+// was changed in place (ie. the element is a pointer or contains one), reporting
+// false if the handle is stale.
+func (this *PriorityQueue[T]) Fix(handle *Handle[T]) bool {
+	if !this.contains(handle) {
+		return false
+	}
+	heap.Fix(this.items, handle.index)
+	return true
+}
+
+// Remove takes the element referred to by handle out of the queue, reporting false
+// if the handle is stale.
+func (this *PriorityQueue[T]) Remove(handle *Handle[T]) (result T, ok bool) {
+	if !this.contains(handle) {
+		return result, false
+	}
+	return heap.Remove(this.items, handle.index).(*Handle[T]).value, true
+}
+
+// Drain pops and yields elements in priority order until the queue is empty
+// (or iteration stops early, in which case the remaining elements stay queued).
+func (this *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := this.Pop()
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func (this *PriorityQueue[T]) contains(handle *Handle[T]) bool {
+	return handle != nil &&
+		handle.index >= 0 &&
+		handle.index < this.Len() &&
+		this.items.handles[handle.index] == handle
+}
+
+// items implements heap.Interface, keeping each handle's index current.
+type items[T any] struct {
+	handles []*Handle[T]
+	less    func(a, b T) bool
+}
+
+func (this *items[T]) Len() int { return len(this.handles) }
+func (this *items[T]) Less(i, j int) bool {
+	return this.less(this.handles[i].value, this.handles[j].value)
+}
+func (this *items[T]) Swap(i, j int) {
+	this.handles[i], this.handles[j] = this.handles[j], this.handles[i]
+	this.handles[i].index = i
+	this.handles[j].index = j
+}
+func (this *items[T]) Push(x any) {
+	handle := x.(*Handle[T])
+	handle.index = len(this.handles)
+	this.handles = append(this.handles, handle)
+}
+func (this *items[T]) Pop() any {
+	last := this.handles[len(this.handles)-1]
+	this.handles[len(this.handles)-1] = nil
+	this.handles = this.handles[:len(this.handles)-1]
+	last.index = -1
+	return last
+}
@@ -0,0 +1,92 @@
+package pq_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/pq"
+)
+
+func less(a, b int) bool { return a < b }
+
+func TestPushPopPeek(t *testing.T) {
+	queue := pq.New(less)
+	_, ok := queue.Pop()
+	should.So(t, ok, should.BeFalse)
+	_, ok = queue.Peek()
+	should.So(t, ok, should.BeFalse)
+
+	for _, n := range []int{5, 1, 4, 2, 3} {
+		queue.Push(n)
+	}
+	should.So(t, queue.Len(), should.Equal, 5)
+	top, ok := queue.Peek()
+	should.So(t, top, should.Equal, 1)
+	should.So(t, ok, should.BeTrue)
+	should.So(t, queue.Len(), should.Equal, 5)
+
+	popped, ok := queue.Pop()
+	should.So(t, popped, should.Equal, 1)
+	should.So(t, ok, should.BeTrue)
+	should.So(t, slices.Collect(queue.Drain()), should.Equal, []int{2, 3, 4, 5})
+	should.So(t, queue.Len(), should.Equal, 0)
+}
+func TestDrainEarlyStop(t *testing.T) {
+	queue := pq.New(func(a, b string) bool { return a > b })
+	for _, s := range []string{"a", "c", "b"} {
+		queue.Push(s)
+	}
+	for s := range queue.Drain() {
+		should.So(t, s, should.Equal, "c")
+		break
+	}
+	should.So(t, slices.Collect(queue.Drain()), should.Equal, []string{"b", "a"})
+}
+func TestUpdate(t *testing.T) {
+	queue := pq.New(less)
+	handles := make(map[int]*pq.Handle[int])
+	for _, n := range []int{10, 20, 30} {
+		handles[n] = queue.Push(n)
+	}
+	should.So(t, handles[30].Value(), should.Equal, 30)
+	should.So(t, queue.Update(handles[30], 5), should.BeTrue)
+	should.So(t, handles[30].Value(), should.Equal, 5)
+	should.So(t, queue.Update(handles[10], 40), should.BeTrue)
+	should.So(t, slices.Collect(queue.Drain()), should.Equal, []int{5, 20, 40})
+	should.So(t, queue.Update(handles[20], 1), should.BeFalse) // stale
+	should.So(t, queue.Len(), should.Equal, 0)
+}
+func TestFix(t *testing.T) {
+	type task struct{ priority int }
+	queue := pq.New(func(a, b *task) bool { return a.priority < b.priority })
+	a := queue.Push(&task{priority: 1})
+	queue.Push(&task{priority: 2})
+	a.Value().priority = 3
+	should.So(t, queue.Fix(a), should.BeTrue)
+	top, _ := queue.Peek()
+	should.So(t, top.priority, should.Equal, 2)
+	queue.Pop()
+	queue.Pop()
+	should.So(t, queue.Fix(a), should.BeFalse)
+}
+func TestRemove(t *testing.T) {
+	queue := pq.New(less)
+	var handles []*pq.Handle[int]
+	for n := range 6 {
+		handles = append(handles, queue.Push(n))
+	}
+	removed, ok := queue.Remove(handles[3])
+	should.So(t, removed, should.Equal, 3)
+	should.So(t, ok, should.BeTrue)
+	_, ok = queue.Remove(handles[3])
+	should.So(t, ok, should.BeFalse)
+	_, ok = queue.Remove(nil)
+	should.So(t, ok, should.BeFalse)
+
+	other := pq.New(less)
+	foreign := other.Push(0)
+	_, ok = queue.Remove(foreign)
+	should.So(t, ok, should.BeFalse)
+	should.So(t, slices.Collect(queue.Drain()), should.Equal, []int{0, 1, 2, 4, 5})
+}
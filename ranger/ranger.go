@@ -1,16 +1,46 @@
 package ranger
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"math/rand/v2"
 	"slices"
+	"sync"
 
-	"github.com/mdw-go/funcy/ranger/internal/ring"
 	"github.com/mdw-go/funcy/ranger/is"
 	"github.com/mdw-go/funcy/ranger/op"
+	"github.com/mdw-go/funcy/ranger/pq"
+	"github.com/mdw-go/funcy/ranger/ring"
 )
 
+func Batch[V any](n int, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		partition(n, n, true, seq, yield)
+	}
+}
+func BottomK[K is.Comparable, V any](k int, key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return selectK(k, key, is.GreaterThan[K], seq)
+}
+func ChunkWhile[V any](pred func(prev, cur V) bool, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var chunk []V
+		for v := range seq {
+			if len(chunk) > 0 && !pred(chunk[len(chunk)-1], v) {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+			chunk = append(chunk, v)
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
 func Complement[V any](predicate func(t V) bool) func(t V) bool {
 	return func(t V) bool { return !predicate(t) }
 }
@@ -25,28 +55,109 @@ func Concat[V any](all ...iter.Seq[V]) iter.Seq[V] {
 		}
 	}
 }
+func ConcatKV[K, V any](all ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, seq := range all {
+			for k, v := range seq {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
 func Count[V any](seq iter.Seq[V]) (result int) {
 	for _ = range seq {
 		result++
 	}
 	return result
 }
+func CountKV[K, V any](seq iter.Seq2[K, V]) (result int) {
+	for _, _ = range seq {
+		result++
+	}
+	return result
+}
+
+// Cycle replays seq endlessly; values from the first pass are retained
+// so that seq itself is only iterated once. Memory therefore grows with the
+// length of seq (without bound for an infinite seq, eg. Cycle(RangeOpen(0, 1))).
+// To replay a restartable seq that is too long to retain, use Flatten(Repeat(seq)),
+// which re-iterates seq instead (and never terminates if seq is empty).
 func Cycle[V any](seq iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
-		for {
-			for s := range seq {
-				if !yield(s) {
+		var seen []V
+		for v := range seq {
+			seen = append(seen, v)
+			if !yield(v) {
+				return
+			}
+		}
+		for len(seen) > 0 {
+			for _, v := range seen {
+				if !yield(v) {
 					return
 				}
 			}
 		}
 	}
 }
+func CycleContext[V any](ctx context.Context, seq iter.Seq[V]) iter.Seq2[V, error] {
+	return WithContext(ctx, Cycle(seq))
+}
+func Dedupe[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var previous V
+		first := true
+		for v := range seq {
+			if !first && v == previous {
+				continue
+			}
+			first = false
+			previous = v
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+func Distinct[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return DistinctBy(func(v V) V { return v }, seq)
+}
+func DistinctBy[K comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		seen := make(map[K]struct{})
+		for v := range seq {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
 func DoAll[V any](f func(V), seq iter.Seq[V]) {
 	for s := range seq {
 		f(s)
 	}
 }
+func DoAllKV[K, V any](f func(K, V), seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		f(k, v)
+	}
+}
+func Drain[V any](ctx context.Context, ch <-chan V) (result []V, err error) {
+	for v, err := range FromChanContext(ctx, ch) {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
 func Drop[V any](n int, s iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		count := 0
@@ -61,8 +172,36 @@ func Drop[V any](n int, s iter.Seq[V]) iter.Seq[V] {
 		}
 	}
 }
+func DropKV[K, V any](n int, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		count := 0
+		for k, v := range s {
+			if count < n {
+				count++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
 func DropLast[V any](n int, s iter.Seq[V]) iter.Seq[V] {
-	return Map2(func(a, _ V) V { return a }, s, Drop(n, s))
+	if n <= 0 {
+		return s
+	}
+	return func(yield func(V) bool) {
+		buffer := ring.New[V](n, ring.Overwrite)
+		for v := range s {
+			if buffer.Full() {
+				oldest, _ := buffer.Pop()
+				if !yield(oldest) {
+					return
+				}
+			}
+			buffer.Push(v)
+		}
+	}
 }
 func DropWhile[V any](pred func(V) bool, s iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -79,6 +218,21 @@ func DropWhile[V any](pred func(V) bool, s iter.Seq[V]) iter.Seq[V] {
 		}
 	}
 }
+func DropWhileKV[K, V any](pred func(K, V) bool, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		dropping := true
+		for k, v := range s {
+			if dropping && pred(k, v) {
+				continue
+			} else if dropping {
+				dropping = false
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
 func Filter[V any](predicate func(V) bool, seq iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		for s := range seq {
@@ -90,9 +244,26 @@ func Filter[V any](predicate func(V) bool, seq iter.Seq[V]) iter.Seq[V] {
 		}
 	}
 }
+func FilterKV[K, V any](predicate func(K, V) bool, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if predicate(k, v) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
 func First[V any](s iter.Seq[V]) V {
 	return Nth(0, s)
 }
+func FirstOk[V any](s iter.Seq[V]) (V, bool) {
+	return NthOk(0, s)
+}
+func FirstOr[V any](fallback V, s iter.Seq[V]) V {
+	return NthOr(0, fallback, s)
+}
 func Flatten[V any](matrix iter.Seq[iter.Seq[V]]) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		for row := range matrix {
@@ -104,6 +275,25 @@ func Flatten[V any](matrix iter.Seq[iter.Seq[V]]) iter.Seq[V] {
 		}
 	}
 }
+func Fold[A, V any](f func(A, V) A, init A, seq iter.Seq[V]) (result A) {
+	result = init
+	for v := range seq {
+		result = f(result, v)
+	}
+	return result
+}
+
+// FoldWhile is like Fold, but stops as soon as f reports false (along with the final accumulator).
+func FoldWhile[A, V any](f func(A, V) (A, bool), init A, seq iter.Seq[V]) (result A) {
+	result = init
+	for v := range seq {
+		var more bool
+		if result, more = f(result, v); !more {
+			break
+		}
+	}
+	return result
+}
 func Frequencies[V comparable](seq iter.Seq[V]) map[V]int {
 	result := make(map[V]int)
 	for s := range seq {
@@ -111,6 +301,34 @@ func Frequencies[V comparable](seq iter.Seq[V]) map[V]int {
 	}
 	return result
 }
+func FromChan[V any](ch <-chan V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+func FromChanContext[V any](ctx context.Context, ch <-chan V) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !yield(v, nil) {
+					return
+				}
+			case <-ctx.Done():
+				var zero V
+				yield(zero, context.Cause(ctx))
+				return
+			}
+		}
+	}
+}
 func GroupBy[K comparable, V any](f func(V) K, seq iter.Seq[V]) map[K][]V {
 	result := make(map[K][]V)
 	for v := range seq {
@@ -119,6 +337,24 @@ func GroupBy[K comparable, V any](f func(V) K, seq iter.Seq[V]) map[K][]V {
 	}
 	return result
 }
+func GroupByOrdered[K comparable, V any](f func(V) K, seq iter.Seq[V]) iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		var keys []K
+		groups := make(map[K][]V)
+		for v := range seq {
+			key := f(v)
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], v)
+		}
+		for _, key := range keys {
+			if !yield(key, groups[key]) {
+				return
+			}
+		}
+	}
+}
 func IndexBy[K comparable, V any](f func(V) K, seq iter.Seq[V]) map[K]V {
 	result := make(map[K]V)
 	for v := range seq {
@@ -163,19 +399,32 @@ func Iterate[V any](f func(V) V, v V) iter.Seq[V] {
 		}
 	}
 }
+func IterateContext[V any](ctx context.Context, f func(V) V, v V) iter.Seq2[V, error] {
+	return WithContext(ctx, Iterate(f, v))
+}
 func Iterator[S ~[]V, V any](s S) iter.Seq[V] {
 	return slices.Values(s)
 }
-func Last[V any](s iter.Seq[V]) (result V) {
-	count := 0
-	for v := range s {
-		result = v
-		count++
+func Keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
 	}
-	if count > 0 {
-		return result
+}
+func Last[V any](s iter.Seq[V]) V {
+	return must(LastOk(s))
+}
+func LastOk[V any](s iter.Seq[V]) (result V, ok bool) {
+	for v := range s {
+		result, ok = v, true
 	}
-	panic("runtime error: index out of range [0] with length 0")
+	return result, ok
+}
+func LastOr[V any](fallback V, s iter.Seq[V]) V {
+	return or(fallback)(LastOk(s))
 }
 func Map[I, O any](f func(I) O, seq iter.Seq[I]) iter.Seq[O] {
 	return func(yield func(O) bool) {
@@ -207,6 +456,42 @@ func Map2[A, B, O any](f func(A, B) O, a iter.Seq[A], b iter.Seq[B]) iter.Seq[O]
 		}
 	}
 }
+func Map3[A, B, C, O any](f func(A, B, C) O, a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		nextC, stopC := iter.Pull(c)
+		defer stopC()
+		for {
+			aa, okA := nextA()
+			if !okA {
+				return
+			}
+			bb, okB := nextB()
+			if !okB {
+				return
+			}
+			cc, okC := nextC()
+			if !okC {
+				return
+			}
+			if !yield(f(aa, bb, cc)) {
+				return
+			}
+		}
+	}
+}
+func MapKV[K, V, KO, VO any](f func(K, V) (KO, VO), seq iter.Seq2[K, V]) iter.Seq2[KO, VO] {
+	return func(yield func(KO, VO) bool) {
+		for k, v := range seq {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
 func MapPairs[M ~map[K]V, K comparable, V any](m M) iter.Seq[Pair[K, V]] {
 	return func(yield func(Pair[K, V]) bool) {
 		for k, v := range m {
@@ -216,23 +501,69 @@ func MapPairs[M ~map[K]V, K comparable, V any](m M) iter.Seq[Pair[K, V]] {
 		}
 	}
 }
-func Max[V is.Comparable](s iter.Seq[V]) (result V) {
-	result = First(s)
-	for s := range Rest(s) {
-		if s > result {
-			result = s
+func Max[V is.Comparable](s iter.Seq[V]) V {
+	return must(MaxOk(s))
+}
+func MaxOk[V is.Comparable](s iter.Seq[V]) (result V, ok bool) {
+	for v := range s {
+		if !ok || v > result {
+			result, ok = v, true
 		}
 	}
-	return result
+	return result, ok
+}
+func MaxOr[V is.Comparable](fallback V, s iter.Seq[V]) V {
+	return or(fallback)(MaxOk(s))
+}
+
+// MergeSorted lazily merges seqs (each already sorted according to compare) into a
+// single sorted sequence, holding only the current head of each input. Ties are
+// yielded in the order of the seqs they came from.
+func MergeSorted[V any](compare func(a, b V) int, seqs ...iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		type head struct {
+			value  V
+			source int
+		}
+		queue := pq.New(func(a, b head) bool {
+			if c := compare(a.value, b.value); c != 0 {
+				return c < 0
+			}
+			return a.source < b.source
+		})
+		nexts := make([]func() (V, bool), len(seqs))
+		for x, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[x] = next
+			if v, ok := next(); ok {
+				queue.Push(head{value: v, source: x})
+			}
+		}
+		for {
+			top, ok := queue.Pop()
+			if !ok || !yield(top.value) {
+				return
+			}
+			if v, ok := nexts[top.source](); ok {
+				queue.Push(head{value: v, source: top.source})
+			}
+		}
+	}
+}
+func Min[V is.Comparable](s iter.Seq[V]) V {
+	return must(MinOk(s))
 }
-func Min[V is.Comparable](s iter.Seq[V]) (result V) {
-	result = First(s)
-	for s := range Rest(s) {
-		if s < result {
-			result = s
+func MinOk[V is.Comparable](s iter.Seq[V]) (result V, ok bool) {
+	for v := range s {
+		if !ok || v < result {
+			result, ok = v, true
 		}
 	}
-	return result
+	return result, ok
+}
+func MinOr[V is.Comparable](fallback V, s iter.Seq[V]) V {
+	return or(fallback)(MinOk(s))
 }
 func Nest[V any](matrix [][]V) iter.Seq[iter.Seq[V]] {
 	return func(yield func(iter.Seq[V]) bool) {
@@ -242,17 +573,18 @@ func Nest[V any](matrix [][]V) iter.Seq[iter.Seq[V]] {
 	}
 }
 func Nth[V any](n int, s iter.Seq[V]) V {
-	if n < 0 {
-		panic(fmt.Sprintf("runtime error: index out of range [%d]", n))
+	v, length, ok := nth(n, s)
+	if !ok {
+		panic(IndexOutOfRangeError{Index: n, Length: length})
 	}
-	c := 0
-	for v := range s {
-		if c == n {
-			return v
-		}
-		c++
-	}
-	panic(fmt.Sprintf("runtime error: index out of range [%d] with length %d", n, c))
+	return v
+}
+func NthOk[V any](n int, s iter.Seq[V]) (V, bool) {
+	v, _, ok := nth(n, s)
+	return v, ok
+}
+func NthOr[V any](n int, fallback V, s iter.Seq[V]) V {
+	return or(fallback)(NthOk(n, s))
 }
 func PairsMap[K comparable, V any](pairs iter.Seq[Pair[K, V]]) map[K]V {
 	result := make(map[K]V)
@@ -261,17 +593,84 @@ func PairsMap[K comparable, V any](pairs iter.Seq[Pair[K, V]]) map[K]V {
 	}
 	return result
 }
-func Partition[V any](chunkLength, stride int, seq iter.Seq[V]) iter.Seq[iter.Seq[V]] {
-	return func(yield func(iter.Seq[V]) bool) {
-		for {
-			chunk := Take(chunkLength, seq)
-			if Count(chunk) < chunkLength {
+func PairsSeq2[K, V any](pairs iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for pair := range pairs {
+			if !yield(pair.A, pair.B) {
 				return
 			}
-			if !yield(chunk) {
-				return
+		}
+	}
+}
+
+// ParallelMap applies f to the values of seq on up to workers goroutines, yielding the
+// results in input order. Once iteration stops early it returns as soon as any in-flight
+// calls to f finish, but the goroutine ranging over seq remains until seq next yields or
+// ends; for a source that may block indefinitely (eg. a channel), prefer one that can be
+// unblocked, such as FromChanContext with a context that is cancelled afterwards.
+func ParallelMap[I, O any](workers int, f func(I) O, seq iter.Seq[I]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		_ = parallelMap(context.Background(), workers, f, seq, yield)
+	}
+}
+
+// ParallelMapContext is like ParallelMap, but ends (yielding context.Cause last) as
+// soon as ctx is done, even while seq is blocked waiting for its next value.
+func ParallelMapContext[I, O any](ctx context.Context, workers int, f func(context.Context, I) O, seq iter.Seq[I]) iter.Seq2[O, error] {
+	return func(yield func(O, error) bool) {
+		err := parallelMap(ctx, workers, func(i I) O { return f(ctx, i) }, seq, func(o O) bool { return yield(o, nil) })
+		if err != nil {
+			var zero O
+			yield(zero, err)
+		}
+	}
+}
+
+// ParallelMapUnordered is like ParallelMap, but yields results as they are ready.
+func ParallelMapUnordered[I, O any](workers int, f func(I) O, seq iter.Seq[I]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		_ = parallelMapUnordered(context.Background(), workers, f, seq, yield)
+	}
+}
+
+// ParallelMapUnorderedContext is like ParallelMapUnordered, but ends (yielding
+// context.Cause last) as soon as ctx is done, even while seq is blocked.
+func ParallelMapUnorderedContext[I, O any](ctx context.Context, workers int, f func(context.Context, I) O, seq iter.Seq[I]) iter.Seq2[O, error] {
+	return func(yield func(O, error) bool) {
+		err := parallelMapUnordered(ctx, workers, func(i I) O { return f(ctx, i) }, seq, func(o O) bool { return yield(o, nil) })
+		if err != nil {
+			var zero O
+			yield(zero, err)
+		}
+	}
+}
+func Partition[V any](chunkLength, stride int, seq iter.Seq[V]) iter.Seq[iter.Seq[V]] {
+	return func(yield func(iter.Seq[V]) bool) {
+		partition(chunkLength, stride, false, seq, func(chunk []V) bool { return yield(Iterator(chunk)) })
+	}
+}
+func PartitionAll[V any](chunkLength, stride int, seq iter.Seq[V]) iter.Seq[iter.Seq[V]] {
+	return func(yield func(iter.Seq[V]) bool) {
+		partition(chunkLength, stride, true, seq, func(chunk []V) bool { return yield(Iterator(chunk)) })
+	}
+}
+func PartitionBy[K comparable, V any](f func(V) K, seq iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var chunk []V
+		var previous K
+		for v := range seq {
+			key := f(v)
+			if len(chunk) > 0 && key != previous {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
 			}
-			seq = Drop(stride, seq)
+			previous = key
+			chunk = append(chunk, v)
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
 		}
 	}
 }
@@ -279,7 +678,22 @@ func Product[N is.Number](seq iter.Seq[N]) N {
 	return Reduce(op.Mul[N], N(1), seq)
 }
 func RandNth[V any](s iter.Seq[V]) V {
-	return Nth(rand.N(Count(s)), s)
+	return must(RandNthOk(s))
+}
+
+// RandNthOk selects a uniformly random element in a single pass (reservoir sampling).
+func RandNthOk[V any](s iter.Seq[V]) (result V, ok bool) {
+	count := 0
+	for v := range s {
+		count++
+		if rand.N(count) == 0 {
+			result, ok = v, true
+		}
+	}
+	return result, ok
+}
+func RandNthOr[V any](fallback V, s iter.Seq[V]) V {
+	return or(fallback)(RandNthOk(s))
 }
 func Range[N is.Number](start, stop N) iter.Seq[N] {
 	var step N = 1
@@ -297,6 +711,9 @@ func RangeOpen[N is.Number](start, step N) iter.Seq[N] {
 		}
 	}
 }
+func RangeOpenContext[N is.Number](ctx context.Context, start, step N) iter.Seq2[N, error] {
+	return WithContext(ctx, RangeOpen(start, step))
+}
 func RangeStep[N is.Number](start, stop, step N) iter.Seq[N] {
 	return func(yield func(N) bool) {
 		for x := start; x != stop; x += step {
@@ -313,6 +730,23 @@ func Reduce[V any](calc func(a, b V) V, start V, seq iter.Seq[V]) (result V) {
 	}
 	return result
 }
+func ReduceKV[K, V, A any](calc func(a A, k K, v V) A, start A, seq iter.Seq2[K, V]) (result A) {
+	result = start
+	for k, v := range seq {
+		result = calc(result, k, v)
+	}
+	return result
+}
+func ReduceNoInit[V any](calc func(a, b V) V, seq iter.Seq[V]) (result V, ok bool) {
+	for v := range seq {
+		if !ok {
+			result, ok = v, true
+			continue
+		}
+		result = calc(result, v)
+	}
+	return result, ok
+}
 func Reductions[V any](calc func(a, b V) V, start V, seq iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		result := start
@@ -330,6 +764,9 @@ func Remove[V any](predicate func(V) bool, seq iter.Seq[V]) iter.Seq[V] {
 func Repeat[V any](v V) iter.Seq[V] {
 	return Repeatedly(func() V { return v })
 }
+func RepeatContext[V any](ctx context.Context, v V) iter.Seq2[V, error] {
+	return WithContext(ctx, Repeat(v))
+}
 func RepeatN[V any](n int, v V) iter.Seq[V] {
 	return Take(n, Repeatedly(func() V { return v }))
 }
@@ -339,46 +776,112 @@ func Repeatedly[V any](v func() V) iter.Seq[V] {
 		}
 	}
 }
+func RepeatedlyContext[V any](ctx context.Context, f func(context.Context) V) iter.Seq2[V, error] {
+	return WithContext(ctx, Repeatedly(func() V { return f(ctx) }))
+}
 func Rest[V any](s iter.Seq[V]) iter.Seq[V] {
 	return Drop(1, s)
 }
+func Scan[A, V any](f func(A, V) A, init A, seq iter.Seq[V]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		result := init
+		for v := range seq {
+			result = f(result, v)
+			if !yield(result) {
+				return
+			}
+		}
+	}
+}
+func Seq2Pairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Pair[K, V]{A: k, B: v}) {
+				return
+			}
+		}
+	}
+}
 func Slice[V any](seq iter.Seq[V]) (result []V) {
 	return slices.Collect(seq)
 }
+func SortBy[K is.Comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return SortFunc(func(a, b V) int { return cmp.Compare(key(a), key(b)) }, seq)
+}
+func SortDescBy[K is.Comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return SortFunc(func(a, b V) int { return cmp.Compare(key(b), key(a)) }, seq)
+}
+func SortFunc[V any](compare func(a, b V) int, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range slices.SortedFunc(seq, compare) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+func SortStableBy[K is.Comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return SortStableFunc(func(a, b V) int { return cmp.Compare(key(a), key(b)) }, seq)
+}
+func SortStableDescBy[K is.Comparable, V any](key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return SortStableFunc(func(a, b V) int { return cmp.Compare(key(b), key(a)) }, seq)
+}
+func SortStableFunc[V any](compare func(a, b V) int, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range slices.SortedStableFunc(seq, compare) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
 func Sum[N is.Number](seq iter.Seq[N]) N {
 	return Reduce(op.Add[N], N(0), seq)
 }
 func Take[V any](n int, s iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
+		if n <= 0 {
+			return
+		}
 		count := 0
 		for v := range s {
+			if !yield(v) {
+				return
+			}
+			count++
 			if count >= n {
 				return
 			}
-			if !yield(v) {
+		}
+	}
+}
+func TakeKV[K, V any](n int, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for k, v := range s {
+			if !yield(k, v) {
 				return
 			}
 			count++
+			if count >= n {
+				return
+			}
 		}
 	}
 }
 func TakeLast[V any](n int, s iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
-		r := ring.New[V](n)
-		count := 0
+		buffer := ring.New[V](n, ring.Overwrite)
 		for v := range s {
-			count++
-			r.Value = v
-			r = r.Next()
-		}
-		for x := 0; x < count; x++ {
-			r = r.Next()
+			buffer.Push(v)
 		}
-		for j := 0; j < count; j++ {
-			if !yield(r.Value) {
+		for v := range buffer.All() {
+			if !yield(v) {
 				return
 			}
-			r = r.Next()
 		}
 	}
 }
@@ -394,9 +897,114 @@ func TakeWhile[V any](pred func(V) bool, s iter.Seq[V]) iter.Seq[V] {
 		}
 	}
 }
+func TakeWhileKV[K, V any](pred func(K, V) bool, s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if !pred(k, v) {
+				return
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// ToChan sends each value of seq on the returned channel from a new goroutine,
+// closing the channel once seq is exhausted or ctx is done. Consumers that stop
+// receiving early must cancel ctx to release the goroutine.
+func ToChan[V any](ctx context.Context, seq iter.Seq[V], buffer int) <-chan V {
+	result := make(chan V, max(buffer, 0))
+	go func() {
+		defer close(result)
+		for v := range seq {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case result <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result
+}
+func TopK[K is.Comparable, V any](k int, key func(V) K, seq iter.Seq[V]) iter.Seq[V] {
+	return selectK(k, key, is.LessThan[K], seq)
+}
+
+// Unzip splits pairs into a sequence of the A values and a sequence of the B values.
+// The pairs are iterated only once, starting when either side is first iterated and
+// thereafter on demand by whichever side is ahead; values not yet consumed by the side
+// that lags behind are buffered. Each of the returned sequences may be iterated only
+// once (a second iteration panics). pairs is released once both sides have finished
+// or stopped, so a side that is never iterated keeps pairs open after the other stops
+// early; break out of it immediately when its values are not needed.
+func Unzip[A, B any](pairs iter.Seq[Pair[A, B]]) (iter.Seq[A], iter.Seq[B]) {
+	u := &unzipper[A, B]{source: pairs, a: unzipped[A]{wanted: true}, b: unzipped[B]{wanted: true}}
+	return unzipSide(u, &u.a, &u.b), unzipSide(u, &u.b, &u.a)
+}
+func Values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
 func Variadic[V any](vs ...V) iter.Seq[V] {
 	return Iterator(vs)
 }
+
+// WithContext yields the values of seq until ctx is done, and then context.Cause.
+// ctx is only checked as seq produces each value (and when it ends), so a seq that
+// blocks (eg. FromChan of an idle channel) delays cancellation until it yields again;
+// use FromChanContext for channel sources that must stop promptly.
+func WithContext[V any](ctx context.Context, seq iter.Seq[V]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for v := range seq {
+			if ctx.Err() != nil {
+				break
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			var zero V
+			yield(zero, context.Cause(ctx))
+		}
+	}
+}
+func Zip3[A, B, C any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[Triple[A, B, C]] {
+	return Map3(func(a A, b B, c C) Triple[A, B, C] { return Triple[A, B, C]{A: a, B: b, C: c} }, a, b, c)
+}
+func ZipLongest[A, B any](fillA A, fillB B, a iter.Seq[A], b iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		for {
+			aa, okA := nextA()
+			bb, okB := nextB()
+			if !okA && !okB {
+				return
+			}
+			if !okA {
+				aa = fillA
+			}
+			if !okB {
+				bb = fillB
+			}
+			if !yield(Pair[A, B]{A: aa, B: bb}) {
+				return
+			}
+		}
+	}
+}
 func ZipMap[K comparable, V any](k iter.Seq[K], v iter.Seq[V]) map[K]V {
 	nextA, stopA := iter.Pull(k)
 	defer stopA()
@@ -416,6 +1024,32 @@ func ZipMap[K comparable, V any](k iter.Seq[K], v iter.Seq[V]) map[K]V {
 	}
 	return result
 }
+func ZipN[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+		nexts := make([]func() (V, bool), len(seqs))
+		for x, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[x] = next
+		}
+		for {
+			row := make([]V, len(nexts))
+			for x, next := range nexts {
+				v, ok := next()
+				if !ok {
+					return
+				}
+				row[x] = v
+			}
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
 func ZipPairs[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[Pair[A, B]] {
 	return func(yield func(Pair[A, B]) bool) {
 		nextA, stopA := iter.Pull(a)
@@ -438,7 +1072,341 @@ func ZipPairs[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[Pair[A, B]] {
 	}
 }
 
+func partition[V any](chunkLength, stride int, partial bool, seq iter.Seq[V], yield func([]V) bool) {
+	if chunkLength <= 0 || stride <= 0 {
+		return
+	}
+	window := make([]V, 0, chunkLength)
+	skip := 0
+	for v := range seq {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		window = append(window, v)
+		if len(window) < chunkLength {
+			continue
+		}
+		if !yield(slices.Clone(window)) {
+			return
+		}
+		if stride >= chunkLength {
+			skip = stride - chunkLength
+			window = window[:0]
+		} else {
+			window = append(window[:0], window[stride:]...)
+		}
+	}
+	for partial && len(window) > 0 {
+		if !yield(slices.Clone(window)) {
+			return
+		}
+		window = window[min(stride, len(window)):]
+	}
+}
+func must[V any](v V, ok bool) V {
+	if !ok {
+		panic(IndexOutOfRangeError{Index: 0, Length: 0})
+	}
+	return v
+}
+func nth[V any](n int, s iter.Seq[V]) (result V, length int, ok bool) {
+	if n < 0 {
+		return result, -1, false
+	}
+	for v := range s {
+		if length == n {
+			return v, length, true
+		}
+		length++
+	}
+	return result, length, false
+}
+func or[V any](fallback V) func(V, bool) V {
+	return func(v V, ok bool) V {
+		if ok {
+			return v
+		}
+		return fallback
+	}
+}
+
+// parallelMap and parallelMapUnordered wait (before returning) only for their workers,
+// which finish their current call to f and then observe done. The producer, which ranges
+// over seq, is deliberately not waited for: while seq blocks it cannot observe done, so it
+// exits the next time seq yields (or ends) instead.
+func parallelMap[I, O any](ctx context.Context, workers int, f func(I) O, seq iter.Seq[I], yield func(O) bool) error {
+	workers = max(workers, 1)
+	type job struct {
+		value  I
+		result chan O
+	}
+	done := make(chan struct{})
+	jobs := make(chan job)
+	pending := make(chan chan O, workers)
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for v := range seq {
+			result := make(chan O, 1)
+			select {
+			case pending <- result:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- job{value: v, result: result}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case j, ok := <-jobs:
+					if !ok {
+						return
+					}
+					j.result <- f(j.value)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(done)
+	for {
+		var result chan O
+		select {
+		case next, ok := <-pending:
+			if !ok {
+				return context.Cause(ctx)
+			}
+			result = next
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+		select {
+		case o := <-result:
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			if !yield(o) {
+				return nil
+			}
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+func parallelMapUnordered[I, O any](ctx context.Context, workers int, f func(I) O, seq iter.Seq[I], yield func(O) bool) error {
+	workers = max(workers, 1)
+	done := make(chan struct{})
+	inputs := make(chan I)
+	outputs := make(chan O)
+	go func() {
+		defer close(inputs)
+		for v := range seq {
+			select {
+			case inputs <- v:
+			case <-done:
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-inputs:
+					if !ok {
+						return
+					}
+					select {
+					case outputs <- f(v):
+					case <-done:
+						return
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outputs)
+	}()
+	defer func() {
+		close(done)
+		for range outputs {
+		}
+	}()
+	for {
+		select {
+		case o, ok := <-outputs:
+			if !ok {
+				return context.Cause(ctx)
+			}
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			if !yield(o) {
+				return nil
+			}
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// selectK retains the k elements of seq that rank last according to less,
+// using a priority queue headed by the current weakest candidate, and yields them
+// strongest first.
+func selectK[K is.Comparable, V any](k int, key func(V) K, less func(a, b K) bool, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if k <= 0 {
+			return
+		}
+		candidates := pq.New(func(a, b Pair[K, V]) bool { return less(a.A, b.A) })
+		for v := range seq {
+			item := Pair[K, V]{A: key(v), B: v}
+			if candidates.Len() < k {
+				candidates.Push(item)
+			} else if weakest, _ := candidates.Peek(); less(weakest.A, item.A) {
+				candidates.Pop()
+				candidates.Push(item)
+			}
+		}
+		result := make([]V, candidates.Len())
+		for x := len(result) - 1; x >= 0; x-- {
+			item, _ := candidates.Pop()
+			result[x] = item.B
+		}
+		for _, v := range result {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func unzipSide[A, B, V, W any](u *unzipper[A, B], side *unzipped[V], other *unzipped[W]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		u.mutex.Lock()
+		if side.started {
+			u.mutex.Unlock()
+			panic(errUnzipReiterated)
+		}
+		side.started = true
+		u.mutex.Unlock()
+		defer func() {
+			u.mutex.Lock()
+			defer u.mutex.Unlock()
+			side.wanted, side.queue = false, nil
+			if !other.wanted {
+				u.finish()
+			}
+		}()
+		for {
+			u.mutex.Lock()
+			for len(side.queue) == 0 && side.wanted && u.pull() {
+			}
+			if len(side.queue) == 0 {
+				u.mutex.Unlock()
+				return
+			}
+			v := side.queue[0]
+			side.queue = side.queue[1:]
+			u.mutex.Unlock()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ErrIndexOutOfRange is matched (via errors.Is) by the values that First, Last, Nth,
+// Max, Min and RandNth panic with when the requested element does not exist.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+var errUnzipReiterated = errors.New("ranger: a sequence returned by Unzip may only be iterated once")
+
+type IndexOutOfRangeError struct {
+	Index  int
+	Length int // -1 when the index was negative (and the length was never counted)
+}
+
+func (this IndexOutOfRangeError) Error() string {
+	if this.Length < 0 {
+		return fmt.Sprintf("runtime error: index out of range [%d]", this.Index)
+	}
+	return fmt.Sprintf("runtime error: index out of range [%d] with length %d", this.Index, this.Length)
+}
+func (this IndexOutOfRangeError) Unwrap() error { return ErrIndexOutOfRange }
+
 type Pair[A, B any] struct {
 	A A
 	B B
 }
+
+type Triple[A, B, C any] struct {
+	A A
+	B B
+	C C
+}
+
+type unzipper[A, B any] struct {
+	mutex  sync.Mutex
+	source iter.Seq[Pair[A, B]]
+	next   func() (Pair[A, B], bool)
+	stop   func()
+	done   bool
+	a      unzipped[A]
+	b      unzipped[B]
+}
+type unzipped[V any] struct {
+	queue   []V
+	wanted  bool
+	started bool
+}
+
+// pull advances the source by one pair (queueing its values for whichever sides are
+// still wanted), reporting false once the source is exhausted. Callers hold the mutex.
+func (this *unzipper[A, B]) pull() bool {
+	if this.done {
+		return false
+	}
+	if this.next == nil {
+		this.next, this.stop = iter.Pull(this.source)
+	}
+	pair, ok := this.next()
+	if !ok {
+		this.finish()
+		return false
+	}
+	if this.a.wanted {
+		this.a.queue = append(this.a.queue, pair.A)
+	}
+	if this.b.wanted {
+		this.b.queue = append(this.b.queue, pair.B)
+	}
+	return true
+}
+func (this *unzipper[A, B]) finish() {
+	if !this.done {
+		this.done = true
+		if this.stop != nil {
+			this.stop()
+		}
+	}
+}
@@ -1,13 +1,23 @@
 package ranger
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"runtime"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mdw-go/funcy/ranger/internal/should"
 	"github.com/mdw-go/funcy/ranger/is"
+	"github.com/mdw-go/funcy/ranger/op"
 )
 
 var (
@@ -25,17 +35,81 @@ var (
 	_nil       = []int(nil)
 )
 
+func TestBatch(t *testing.T) {
+	batches := Slice(Batch(2, once(Range(1, 6))))
+	should.So(t, batches, should.Equal, [][]int{{1, 2}, {3, 4}, {5}})
+	batches[0][0] = 42
+	should.So(t, batches[1], should.Equal, []int{3, 4})
+	should.So(t, Slice(Take(1, Batch(2, RangeOpen(1, 1)))), should.Equal, [][]int{{1, 2}})
+	should.So(t, Slice(Batch(2, Range(0, 0))), should.BeEmpty)
+}
+func TestBottomK(t *testing.T) {
+	identity := func(n int) int { return n }
+	should.So(t, Slice(BottomK(3, identity, once(Variadic(5, 1, 9, 3, 7, 2)))), should.Equal, []int{1, 2, 3})
+	should.So(t, Slice(BottomK(10, identity, Variadic(5, 1, 9))), should.Equal, []int{1, 5, 9})
+	should.So(t, Slice(BottomK(-1, identity, Variadic(5, 1, 9))), should.Equal, _nil)
+}
+func TestChunkWhile(t *testing.T) {
+	consecutive := func(prev, cur int) bool { return cur == prev+1 }
+	should.So(t, Slice(ChunkWhile(consecutive, once(Variadic(1, 2, 4, 9, 10, 11, 12, 15)))), should.Equal, [][]int{{1, 2}, {4}, {9, 10, 11, 12}, {15}})
+	should.So(t, Slice(Take(2, ChunkWhile(consecutive, Variadic(1, 2, 4, 9)))), should.Equal, [][]int{{1, 2}, {4}})
+	should.So(t, Slice(ChunkWhile(consecutive, Range(0, 0))), should.BeEmpty)
+}
 func TestConcat(t *testing.T) {
 	should.So(t,
 		Slice(Take(12, Concat(Range(0, 5), Range(5, 10), Range(10, 15)))), should.Equal,
 		Slice(Range(0, 12)),
 	)
 }
+func TestContextSources(t *testing.T) {
+	cause := errors.New("cause")
+	sources := map[string]func(context.Context) iter.Seq2[int, error]{
+		"WithContext":      func(ctx context.Context) iter.Seq2[int, error] { return WithContext(ctx, RangeOpen(0, 1)) },
+		"RangeOpenContext": func(ctx context.Context) iter.Seq2[int, error] { return RangeOpenContext(ctx, 0, 1) },
+		"IterateContext":   func(ctx context.Context) iter.Seq2[int, error] { return IterateContext(ctx, op.Square[int], 1) },
+		"RepeatContext":    func(ctx context.Context) iter.Seq2[int, error] { return RepeatContext(ctx, 1) },
+		"CycleContext":     func(ctx context.Context) iter.Seq2[int, error] { return CycleContext(ctx, Range(0, 2)) },
+		"RepeatedlyContext": func(ctx context.Context) iter.Seq2[int, error] {
+			return RepeatedlyContext(ctx, func(context.Context) int { return 1 })
+		},
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancelCause(context.Background())
+			values, err := collectErr(source(ctx), cancelAt(4, cancel, cause))
+			should.So(t, len(values), should.Equal, 5)
+			should.So(t, err, should.Equal, cause)
+		})
+	}
+	values, err := collectErr(WithContext(context.Background(), Range(0, 3)))
+	should.So(t, values, should.Equal, []int{0, 1, 2})
+	should.So(t, err, should.BeNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	values, err = collectErr(CycleContext(ctx, Range(0, 3)))
+	should.So(t, values, should.Equal, _nil)
+	should.So(t, err, should.Equal, context.Canceled)
+}
 func TestCount(t *testing.T) {
 	should.So(t, Count(Range(0, 20)), should.Equal, 20)
 }
 func TestCycle(t *testing.T) {
 	should.So(t, Slice(Take(9, Cycle(Range(0, 2)))), should.Equal, []int{0, 1, 0, 1, 0, 1, 0, 1, 0})
+	should.So(t, Slice(Take(7, Cycle(once(Range(0, 3))))), should.Equal, []int{0, 1, 2, 0, 1, 2, 0})
+	should.So(t, Slice(Cycle(Range(0, 0))), should.Equal, _nil)
+	should.So(t, Slice(Take(5, Flatten(Repeat(Range(0, 2))))), should.Equal, []int{0, 1, 0, 1, 0})
+}
+func TestDedupe(t *testing.T) {
+	should.So(t, Slice(Dedupe(once(Variadic(1, 1, 2, 2, 2, 1, 3, 3)))), should.Equal, []int{1, 2, 1, 3})
+	should.So(t, Slice(Dedupe(Variadic(0, 0, 1))), should.Equal, []int{0, 1})
+	should.So(t, Slice(Take(2, Dedupe(Cycle(Variadic(1, 1, 2))))), should.Equal, _12)
+	should.So(t, Slice(Dedupe(Range(0, 0))), should.Equal, _nil)
+}
+func TestDistinct(t *testing.T) {
+	should.So(t, Slice(Distinct(once(Variadic(3, 1, 3, 2, 1, 4)))), should.Equal, []int{3, 1, 2, 4})
+	should.So(t, Slice(Take(3, Distinct(Cycle(Variadic(1, 2, 3))))), should.Equal, _123)
+	should.So(t, Slice(DistinctBy(strings.ToLower, once(Variadic("a", "B", "A", "b", "c")))), should.Equal, []string{"a", "B", "c"})
 }
 func TestDoAll(t *testing.T) {
 	var all []int
@@ -45,6 +119,20 @@ func TestDoAll(t *testing.T) {
 	DoAll(store, Range(1, 10))
 	should.So(t, all, should.Equal, _123456789)
 }
+func TestDrain(t *testing.T) {
+	values, err := Drain(context.Background(), ToChan(context.Background(), Range(0, 5), 0))
+	should.So(t, values, should.Equal, Slice(Range(0, 5)))
+	should.So(t, err, should.BeNil)
+
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	values, err = Drain(ctx, ch)
+	should.So(t, values, should.Equal, _12)
+	should.So(t, err, should.Equal, context.DeadlineExceeded)
+}
 func TestDrop(t *testing.T) {
 	should.So(t, Slice(Drop(4, Range(0, 10))), should.Equal, _456789)
 	should.So(t, Slice(Drop(8, Range(1, 5))), should.Equal, _nil)
@@ -53,6 +141,9 @@ func TestDrop(t *testing.T) {
 }
 func TestDropLast(t *testing.T) {
 	should.So(t, Slice(Take(5, DropLast(4, Range(1, 11)))), should.Equal, _12345)
+	should.So(t, Slice(DropLast(2, once(Range(1, 6)))), should.Equal, _123)
+	should.So(t, Slice(DropLast(0, once(Range(1, 4)))), should.Equal, _123)
+	should.So(t, Slice(DropLast(5, once(Range(1, 4)))), should.Equal, _nil)
 }
 func TestDropWhile(t *testing.T) {
 	should.So(t, Slice(Take(3, DropWhile(is.Even[int], Iterator([]int{0, 2, 4, 6, 8, 1, 3, 5, 7})))), should.Equal, _135)
@@ -65,6 +156,14 @@ func TestFilter(t *testing.T) {
 func TestFirst(t *testing.T) {
 	should.So(t, func() { First(Take(0, Range(0, 10))) }, should.Panic)
 	should.So(t, First(Drop(1, Range(1, 10))), should.Equal, 2)
+	should.So(t, FirstOr(-1, Range(0, 0)), should.Equal, -1)
+	should.So(t, FirstOr(-1, Range(5, 10)), should.Equal, 5)
+	v, ok := FirstOk(Range(0, 0))
+	should.So(t, v, should.Equal, 0)
+	should.So(t, ok, should.BeFalse)
+	v, ok = FirstOk(once(Range(5, 10)))
+	should.So(t, v, should.Equal, 5)
+	should.So(t, ok, should.BeTrue)
 }
 func TestFlatten(t *testing.T) {
 	should.So(t, Slice(Take(12, Flatten(Nest([][]int{
@@ -73,6 +172,21 @@ func TestFlatten(t *testing.T) {
 		{10, 11, 12, 13, 14},
 	})))), should.Equal, Slice(Range(0, 12)))
 }
+func TestFold(t *testing.T) {
+	count := func(counts map[string]int, word string) map[string]int {
+		counts[word]++
+		return counts
+	}
+	should.So(t, Fold(count, map[string]int{}, once(Variadic("a", "b", "a"))), should.Equal, map[string]int{"a": 2, "b": 1})
+	should.So(t, Fold(func(a string, n int) string { return a + strconv.Itoa(n) }, ">", Range(1, 4)), should.Equal, ">123")
+	should.So(t, Fold(func(a string, n int) string { return a + strconv.Itoa(n) }, ">", Range(0, 0)), should.Equal, ">")
+}
+func TestFoldWhile(t *testing.T) {
+	underTen := func(sum, n int) (int, bool) { return sum + n, sum+n < 10 }
+	should.So(t, FoldWhile(underTen, 0, RangeOpen(1, 1)), should.Equal, 10)
+	should.So(t, FoldWhile(underTen, 0, Range(1, 3)), should.Equal, 3)
+	should.So(t, FoldWhile(underTen, 42, Range(0, 0)), should.Equal, 42)
+}
 func TestFrequencies(t *testing.T) {
 	should.So(t, Frequencies(Variadic(1, 1, 2, 2, 2, 3, 4, 4)), should.Equal, map[int]int{
 		1: 2,
@@ -81,6 +195,21 @@ func TestFrequencies(t *testing.T) {
 		4: 2,
 	})
 }
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 5)
+	for n := range Range(0, 5) {
+		ch <- n
+	}
+	close(ch)
+	should.So(t, Slice(FromChan(ch)), should.Equal, []int{0, 1, 2, 3, 4})
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cause := errors.New("cause")
+	cancel(cause)
+	values, err := collectErr(FromChanContext(ctx, make(chan int)))
+	should.So(t, values, should.Equal, _nil)
+	should.So(t, err, should.Equal, cause)
+}
 func TestGroupBy(t *testing.T) {
 	should.So(t, GroupBy(strconv.Itoa, Concat(Range(0, 5), Range(1, 4))), should.Equal,
 		map[string][]int{
@@ -92,11 +221,48 @@ func TestGroupBy(t *testing.T) {
 		},
 	)
 }
+func TestGroupByOrdered(t *testing.T) {
+	var keys []string
+	var groups [][]string
+	for key, group := range GroupByOrdered(func(s string) string { return s[:1] }, once(Variadic("banana", "apple", "blueberry", "cherry", "avocado"))) {
+		keys = append(keys, key)
+		groups = append(groups, group)
+	}
+	should.So(t, keys, should.Equal, []string{"b", "a", "c"})
+	should.So(t, groups, should.Equal, [][]string{{"banana", "blueberry"}, {"apple", "avocado"}, {"cherry"}})
+	should.So(t, Slice(Keys(TakeKV(1, GroupByOrdered(is.Even[int], Range(1, 5))))), should.Equal, []bool{false})
+}
 func TestIndexBy(t *testing.T) {
 	should.So(t, IndexBy(strconv.Itoa, Range(0, 5)), should.Equal,
 		map[string]int{"0": 0, "1": 1, "2": 2, "3": 3, "4": 4},
 	)
 }
+func TestIndexOutOfRangeError(t *testing.T) {
+	recovered := func(f func()) (err error) {
+		defer func() { err, _ = recover().(error) }()
+		f()
+		return nil
+	}
+	err := recovered(func() { Nth(4, Iterator(_1234)) })
+	should.So(t, errors.Is(err, ErrIndexOutOfRange), should.BeTrue)
+	should.So(t, err, should.Equal, IndexOutOfRangeError{Index: 4, Length: 4})
+	should.So(t, err.Error(), should.Equal, "runtime error: index out of range [4] with length 4")
+
+	err = recovered(func() { Nth(-1, Iterator(_1234)) })
+	should.So(t, err.Error(), should.Equal, "runtime error: index out of range [-1]")
+
+	for _, f := range []func(){
+		func() { First(Range(0, 0)) },
+		func() { Last(Range(0, 0)) },
+		func() { Max(Range(0, 0)) },
+		func() { Min(Range(0, 0)) },
+		func() { RandNth(Range(0, 0)) },
+	} {
+		err = recovered(f)
+		should.So(t, errors.Is(err, ErrIndexOutOfRange), should.BeTrue)
+		should.So(t, err.Error(), should.Equal, "runtime error: index out of range [0] with length 0")
+	}
+}
 func TestInterleave(t *testing.T) {
 	should.So(t, Slice(Take(5, Interleave(Range(0, 10), Range(10, 20)))), should.Equal, []int{0, 10, 1, 11, 2})
 	should.So(t, Slice(Take(6, Interleave(Range(0, 10), Range(10, 20)))), should.Equal, []int{0, 10, 1, 11, 2, 12})
@@ -125,9 +291,35 @@ func TestIterator(t *testing.T) {
 	should.So(t, Slice(Take(2, Iterator(_123))), should.Equal, _12)
 	should.So(t, Slice(Variadic(1, 2, 3)), should.Equal, _123)
 }
+func TestKV(t *testing.T) {
+	letters := []string{"a", "b", "c", "d", "e"}
+	even := func(k int, _ string) bool { return is.Even(k) }
+	swap := func(k int, v string) (string, int) { return v, k }
+	should.So(t, CountKV(slices.All(letters)), should.Equal, 5)
+	should.So(t, Slice(Keys(TakeKV(3, slices.All(letters)))), should.Equal, []int{0, 1, 2})
+	should.So(t, Slice(Values(DropKV(3, slices.All(letters)))), should.Equal, []string{"d", "e"})
+	should.So(t, Slice(Values(FilterKV(even, slices.All(letters)))), should.Equal, []string{"a", "c", "e"})
+	should.So(t, Slice(Values(TakeWhileKV(func(k int, _ string) bool { return k < 2 }, slices.All(letters)))), should.Equal, []string{"a", "b"})
+	should.So(t, Slice(Keys(DropWhileKV(func(k int, _ string) bool { return k < 2 }, slices.All(letters)))), should.Equal, []int{2, 3, 4})
+	should.So(t, Slice(Seq2Pairs(MapKV(swap, TakeKV(2, slices.All(letters))))), should.Equal, []Pair[string, int]{{A: "a", B: 0}, {A: "b", B: 1}})
+	should.So(t, Slice(Keys(TakeKV(3, ConcatKV(slices.All(letters[:2]), slices.All(letters[2:]))))), should.Equal, []int{0, 1, 0})
+	should.So(t, ReduceKV(func(a string, k int, v string) string { return a + strconv.Itoa(k) + v }, "", slices.All(letters[:3])), should.Equal, "0a1b2c")
+	should.So(t, maps.Collect(PairsSeq2(ZipPairs(Range(0, 3), Range(10, 13)))), should.Equal, map[int]int{0: 10, 1: 11, 2: 12})
+	should.So(t, Slice(Take(2, Seq2Pairs(PairsSeq2(ZipPairs(Range(0, 3), Range(10, 13)))))), should.Equal, []Pair[int, int]{{A: 0, B: 10}, {A: 1, B: 11}})
+	var all []string
+	DoAllKV(func(k int, v string) { all = append(all, strconv.Itoa(k)+v) }, TakeKV(2, slices.All(letters)))
+	should.So(t, all, should.Equal, []string{"0a", "1b"})
+}
 func TestLast(t *testing.T) {
 	should.So(t, func() { Last(Take(0, Range(0, 10))) }, should.Panic)
 	should.So(t, Last(Take(3, Range(1, 10))), should.Equal, 3)
+	should.So(t, LastOr(-1, Range(0, 0)), should.Equal, -1)
+	should.So(t, LastOr(-1, Range(5, 10)), should.Equal, 9)
+	v, ok := LastOk(Range(0, 0))
+	should.So(t, ok, should.BeFalse)
+	v, ok = LastOk(once(Range(5, 10)))
+	should.So(t, v, should.Equal, 9)
+	should.So(t, ok, should.BeTrue)
 }
 func TestMap(t *testing.T) {
 	square := func(n int) int64 { return int64(n * n) }
@@ -143,6 +335,13 @@ func TestMap2(t *testing.T) {
 	should.So(t, Slice(Map2(add, Range(0, 0), RepeatN(1, 1))), should.Equal, []int(nil))
 	should.So(t, Slice(Map2(add, Range(0, 1), RepeatN(0, 1))), should.Equal, []int(nil))
 }
+func TestMap3(t *testing.T) {
+	sum := func(a, b, c int) int { return a + b + c }
+	should.So(t, Slice(Map3(sum, Range(0, 3), Range(10, 13), Range(100, 200))), should.Equal, []int{110, 113, 116})
+	should.So(t, Slice(Map3(sum, Range(0, 3), Range(0, 0), Range(0, 3))), should.Equal, _nil)
+	should.So(t, Slice(Map3(sum, Range(0, 3), Range(0, 3), Range(0, 1))), should.Equal, []int{0})
+	should.So(t, Slice(Take(1, Map3(sum, RangeOpen(0, 1), RangeOpen(0, 1), RangeOpen(0, 1)))), should.Equal, []int{0})
+}
 func TestMapPairs(t *testing.T) {
 	m := ZipMap(RangeStep(0, 10, 2), RangeStep(1, 11, 2))
 	pairs := Slice(Take(4, MapPairs(m)))
@@ -158,24 +357,160 @@ func TestMax(t *testing.T) {
 	should.So(t, Max(Range(4, 20)), should.Equal, 19)
 	should.So(t, func() { Max(Range(0, 0)) }, should.Panic)
 	should.So(t, Max(Variadic(1, 6, -2, 3, 42, 7)), should.Equal, 42)
+	should.So(t, Max(once(Variadic(1, 6, -2, 3, 42, 7))), should.Equal, 42)
+	should.So(t, MaxOr(-1, Range(0, 0)), should.Equal, -1)
+	should.So(t, MaxOr(-1, Variadic(-5, -7)), should.Equal, -5)
+	_, ok := MaxOk(Range(0, 0))
+	should.So(t, ok, should.BeFalse)
+}
+func TestMergeSorted(t *testing.T) {
+	merged := MergeSorted(cmp.Compare[int], Variadic(1, 4, 7), once(Variadic(2, 5, 8)), Variadic[int](), Variadic(0, 3, 6, 9))
+	should.So(t, Slice(merged), should.Equal, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	should.So(t, Slice(MergeSorted[int](cmp.Compare[int])), should.BeEmpty)
+	should.So(t, Slice(Take(5, MergeSorted(cmp.Compare[int], RangeOpen(0, 2), RangeOpen(1, 2)))), should.Equal, []int{0, 1, 2, 3, 4})
+
+	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	stable := MergeSorted(byLength, Variadic("a", "bb", "cc"), Variadic("d", "ee"))
+	should.So(t, Slice(stable), should.Equal, []string{"a", "d", "bb", "cc", "ee"})
 }
 func TestMin(t *testing.T) {
 	should.So(t, Min(Range(4, 20)), should.Equal, 4)
 	should.So(t, func() { Min(Range(0, 0)) }, should.Panic)
 	should.So(t, Min(Variadic(1, 6, -2, 3, 42)), should.Equal, -2)
+	should.So(t, Min(once(Variadic(1, 6, -2, 3, 42))), should.Equal, -2)
+	should.So(t, MinOr(-1, Range(0, 0)), should.Equal, -1)
+	should.So(t, MinOr(-1, Variadic(5, 7)), should.Equal, 5)
+	_, ok := MinOk(Range(0, 0))
+	should.So(t, ok, should.BeFalse)
 }
 func TestNth(t *testing.T) {
 	should.So(t, func() { Nth(-1, Iterator(_1234)) }, should.Panic)
 	should.So(t, Nth(2, Iterator(_1234)), should.Equal, 3)
+	should.So(t, func() { Nth(4, Iterator(_1234)) }, should.Panic)
+	should.So(t, NthOr(4, -1, Iterator(_1234)), should.Equal, -1)
+	should.So(t, NthOr(-1, -1, Iterator(_1234)), should.Equal, -1)
+	should.So(t, NthOr(1, -1, Iterator(_1234)), should.Equal, 2)
+	v, ok := NthOk(3, once(Iterator(_1234)))
+	should.So(t, v, should.Equal, 4)
+	should.So(t, ok, should.BeTrue)
+	_, ok = NthOk(4, Iterator(_1234))
+	should.So(t, ok, should.BeFalse)
+}
+func TestParallelMap(t *testing.T) {
+	square, peak := gatedSquare(4)
+	should.So(t, Slice(ParallelMap(4, square, Range(0, 20))), should.Equal, Slice(Map(op.Square[int], Range(0, 20))))
+	should.So(t, peak.Load(), should.Equal, int32(4))
+	should.So(t, Slice(ParallelMap(0, op.Square[int], Range(0, 3))), should.Equal, []int{0, 1, 4})
+
+	square, peak = gatedSquare(4)
+	unordered := Slice(ParallelMapUnordered(4, square, Range(0, 20)))
+	slices.Sort(unordered)
+	should.So(t, unordered, should.Equal, Slice(Map(op.Square[int], Range(0, 20))))
+	should.So(t, peak.Load(), should.Equal, int32(4))
+}
+func TestParallelMapBlockingSource(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ordered, unordered := make(chan int, 1), make(chan int, 1)
+	ordered <- 1
+	unordered <- 2
+	should.So(t, returnsPromptly(func() { Slice(Take(1, ParallelMap(2, op.Square[int], FromChan(ordered)))) }), should.BeTrue)
+	should.So(t, returnsPromptly(func() { Slice(Take(1, ParallelMapUnordered(2, op.Square[int], FromChan(unordered)))) }), should.BeTrue)
+	close(ordered)
+	close(unordered)
+	should.So(t, goroutinesSettle(before), should.BeTrue)
+}
+func TestParallelMapContext(t *testing.T) {
+	square := func(_ context.Context, n int) int { return n * n }
+	values, err := collectErr(ParallelMapContext(context.Background(), 4, square, Range(0, 5)))
+	should.So(t, values, should.Equal, []int{0, 1, 4, 9, 16})
+	should.So(t, err, should.BeNil)
+
+	values, err = collectErr(ParallelMapUnorderedContext(context.Background(), 4, square, Range(0, 5)))
+	slices.Sort(values)
+	should.So(t, values, should.Equal, []int{0, 1, 4, 9, 16})
+	should.So(t, err, should.BeNil)
+
+	cause := errors.New("cause")
+	before := runtime.NumGoroutine()
+	blocking := func(ctx context.Context, n int) int {
+		if n == 3 {
+			<-ctx.Done()
+		}
+		return n
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	values, err = collectErr(ParallelMapContext(ctx, 2, blocking, RangeOpen(0, 1)), cancelAt(2, cancel, cause))
+	should.So(t, values, should.Equal, []int{0, 1, 2})
+	should.So(t, err, should.Equal, cause)
+
+	ctx, cancel = context.WithCancelCause(context.Background())
+	values, err = collectErr(ParallelMapUnorderedContext(ctx, 2, blocking, RangeOpen(0, 1)), cancelAt(2, cancel, cause))
+	should.So(t, len(values), should.Equal, 3)
+	should.So(t, err, should.Equal, cause)
+	should.So(t, goroutinesSettle(before), should.BeTrue)
+}
+func TestParallelMapContextBlockingSource(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for _, parallelMap := range []func(context.Context, int, func(context.Context, int) int, iter.Seq[int]) iter.Seq2[int, error]{
+		ParallelMapContext[int, int],
+		ParallelMapUnorderedContext[int, int],
+	} {
+		ch := make(chan int)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var err error
+		should.So(t, returnsPromptly(func() {
+			_, err = collectErr(parallelMap(ctx, 2, func(_ context.Context, n int) int { return n }, FromChan(ch)))
+		}), should.BeTrue)
+		should.So(t, err, should.Equal, context.Canceled)
+		close(ch)
+	}
+	should.So(t, goroutinesSettle(before), should.BeTrue)
+}
+func TestParallelMapEarlyTermination(t *testing.T) {
+	before := runtime.NumGoroutine()
+	should.So(t, Slice(Take(3, ParallelMap(4, op.Square[int], RangeOpen(0, 1)))), should.Equal, []int{0, 1, 4})
+	should.So(t, len(Slice(Take(3, ParallelMapUnordered(4, op.Square[int], RangeOpen(0, 1))))), should.Equal, 3)
+	should.So(t, goroutinesSettle(before), should.BeTrue)
 }
 func TestPartition(t *testing.T) {
 	should.So(t, Slice(Map(Sum[int], Partition(3, 3, Range(1, 10)))), should.Equal, []int{6, 15, 24})
 	should.So(t, Slice(Map(Sum[int], Take(2, Partition(3, 3, Range(1, 10))))), should.Equal, []int{6, 15})
 	should.So(t, Slice(Map(Sum[int], Partition(3, 1, Range(1, 5)))), should.Equal, []int{6, 9})
+	should.So(t, Slice(Map(Slice[int], Partition(2, 3, once(Range(1, 10))))), should.Equal, [][]int{{1, 2}, {4, 5}, {7, 8}})
+	should.So(t, Slice(Map(Slice[int], Partition(3, 2, once(Range(1, 8))))), should.Equal, [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}})
+	should.So(t, Slice(Partition(0, 1, Range(1, 10))), should.BeEmpty)
+	should.So(t, Slice(Partition(1, 0, Range(1, 10))), should.BeEmpty)
+}
+func TestPartitionAll(t *testing.T) {
+	should.So(t, Slice(Map(Slice[int], PartitionAll(3, 3, once(Range(1, 9))))), should.Equal, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8}})
+	should.So(t, Slice(Map(Slice[int], PartitionAll(3, 1, once(Range(1, 5))))), should.Equal, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4}, {4}})
+	should.So(t, Slice(Map(Slice[int], PartitionAll(2, 3, once(Range(1, 9))))), should.Equal, [][]int{{1, 2}, {4, 5}, {7, 8}})
+	should.So(t, Slice(Map(Slice[int], Take(2, PartitionAll(3, 1, Range(1, 5))))), should.Equal, [][]int{{1, 2, 3}, {2, 3, 4}})
+}
+func TestPartitionBy(t *testing.T) {
+	should.So(t, Slice(PartitionBy(is.Odd[int], once(Variadic(1, 3, 2, 4, 5, 7, 6)))), should.Equal, [][]int{{1, 3}, {2, 4}, {5, 7}, {6}})
+	should.So(t, Slice(PartitionBy(is.Odd[int], Variadic(1))), should.Equal, [][]int{{1}})
+	should.So(t, Slice(Take(2, PartitionBy(is.Odd[int], RangeOpen(0, 1)))), should.Equal, [][]int{{0}, {1}})
+	should.So(t, Slice(PartitionBy(is.Odd[int], Range(0, 0))), should.BeEmpty)
 }
 func TestProduct(t *testing.T) {
 	should.So(t, Product(Range(1, 6)), should.Equal, 1*2*3*4*5)
 }
+func TestSort(t *testing.T) {
+	type item struct {
+		Name string
+		Rank int
+	}
+	rank := func(i item) int { return i.Rank }
+	items := []item{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 0}}
+	should.So(t, Slice(SortBy(op.Abs[int], once(Variadic(3, -1, 2, -4)))), should.Equal, []int{-1, 2, 3, -4})
+	should.So(t, Slice(SortDescBy(op.Abs[int], once(Variadic(3, -1, 2, -4)))), should.Equal, []int{-4, 3, 2, -1})
+	should.So(t, Slice(SortFunc(strings.Compare, once(Variadic("b", "c", "a")))), should.Equal, []string{"a", "b", "c"})
+	should.So(t, Slice(SortStableBy(rank, Iterator(items))), should.Equal, []item{{"d", 0}, {"b", 1}, {"a", 2}, {"c", 2}})
+	should.So(t, Slice(SortStableDescBy(rank, Iterator(items))), should.Equal, []item{{"a", 2}, {"c", 2}, {"b", 1}, {"d", 0}})
+	should.So(t, Slice(Take(2, SortStableFunc(func(a, b item) int { return a.Rank - b.Rank }, Iterator(items)))), should.Equal, []item{{"d", 0}, {"b", 1}})
+}
 func TestSum(t *testing.T) {
 	should.So(t, Sum(Range(1, 6)), should.Equal, 1+2+3+4+5)
 }
@@ -185,15 +520,46 @@ func TestTake(t *testing.T) {
 	should.So(t, Slice(Take(8, Range(1, 5))), should.Equal, _1234)
 	should.So(t, Slice(Take(1, Range(0, 0))), should.Equal, _nil)
 	should.So(t, Slice(Take(2, Take(3, Range(1, 10)))), should.Equal, _12)
+	should.So(t, Slice(Take(0, once(Range(1, 5)))), should.Equal, _nil)
+	ch := make(chan int, 4)
+	for n := range Range(1, 5) {
+		ch <- n
+	}
+	close(ch)
+	should.So(t, Slice(Take(2, FromChan(ch))), should.Equal, _12)
+	should.So(t, Slice(FromChan(ch)), should.Equal, []int{3, 4})
 }
 func TestTakeLast(t *testing.T) {
 	should.So(t, Slice(TakeLast(20, Range(0, 10))), should.Equal, Slice(Range(0, 10)))
 	should.So(t, Slice(Take(4, TakeLast(5, Range(0, 10)))), should.Equal, []int{5, 6, 7, 8})
+	should.So(t, Slice(TakeLast(2, Range(0, 10))), should.Equal, []int{8, 9})
+	should.So(t, Slice(TakeLast(0, Range(0, 10))), should.Equal, _nil)
+	should.So(t, Slice(TakeLast(3, once(Range(0, 0)))), should.Equal, _nil)
 }
 func TestTakeWhile(t *testing.T) {
 	should.So(t, Slice(Take(4, TakeWhile(is.Even[int], Iterator([]int{0, 2, 4, 6, 8, 1, 3, 5, 7})))), should.Equal, _0246)
 	should.So(t, Slice(TakeWhile(is.Even[int], Iterator([]int{1, 3, 5, 7, 0, 2, 4, 6, 8}))), should.Equal, _nil)
 }
+func TestToChan(t *testing.T) {
+	should.So(t, Slice(FromChan(ToChan(context.Background(), Range(0, 5), 2))), should.Equal, Slice(Range(0, 5)))
+
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChan(ctx, RangeOpen(0, 1), 0)
+	should.So(t, Slice(Take(3, FromChan(ch))), should.Equal, []int{0, 1, 2})
+	cancel()
+	for range ch {
+	}
+	should.So(t, goroutinesSettle(before), should.BeTrue)
+}
+func TestTopK(t *testing.T) {
+	identity := func(n int) int { return n }
+	should.So(t, Slice(TopK(3, identity, once(Variadic(5, 1, 9, 3, 7, 2)))), should.Equal, []int{9, 7, 5})
+	should.So(t, Slice(TopK(10, identity, Variadic(5, 1, 9))), should.Equal, []int{9, 5, 1})
+	should.So(t, Slice(TopK(0, identity, Variadic(5, 1, 9))), should.Equal, _nil)
+	should.So(t, Slice(TopK(2, strings.ToLower, Variadic("b", "C", "a"))), should.Equal, []string{"C", "b"})
+	should.So(t, Slice(Take(1, TopK(3, identity, Range(0, 1000)))), should.Equal, []int{999})
+}
 func TestRandNth(t *testing.T) {
 	should.So(t, RandNth(Variadic(42)), should.Equal, 42)
 	var values []int
@@ -207,6 +573,15 @@ func TestRandNth(t *testing.T) {
 	for _, n := range _123456789 {
 		should.So(t, freq[n], should.BeGreaterThan, 0)
 	}
+	should.So(t, RandNth(once(Iterator(_123456789))), should.BeIn, _123456789)
+	should.So(t, func() { RandNth(Range(0, 0)) }, should.Panic)
+	should.So(t, RandNthOr(-1, Range(0, 0)), should.Equal, -1)
+	should.So(t, RandNthOr(-1, Variadic(42)), should.Equal, 42)
+	v, ok := RandNthOk(Variadic(42))
+	should.So(t, v, should.Equal, 42)
+	should.So(t, ok, should.BeTrue)
+	_, ok = RandNthOk(Range(0, 0))
+	should.So(t, ok, should.BeFalse)
 }
 func TestRange(t *testing.T) {
 	should.So(t, Slice(Range(1, 10)), should.Equal, _123456789)
@@ -218,6 +593,16 @@ func TestReduce(t *testing.T) {
 	add := func(a, b int) int { return a + b }
 	should.So(t, Reduce(add, 0, Range(1, 6)), should.Equal, 15)
 }
+func TestReduceNoInit(t *testing.T) {
+	result, ok := ReduceNoInit(op.Sub[int], once(Range(10, 13)))
+	should.So(t, result, should.Equal, 10-11-12)
+	should.So(t, ok, should.BeTrue)
+	result, ok = ReduceNoInit(op.Sub[int], Variadic(5))
+	should.So(t, result, should.Equal, 5)
+	should.So(t, ok, should.BeTrue)
+	_, ok = ReduceNoInit(op.Sub[int], Range(0, 0))
+	should.So(t, ok, should.BeFalse)
+}
 func TestReductions(t *testing.T) {
 	add := func(a int, b int) int { return a + b }
 	should.So(t, Slice(Take(5, Reductions(add, 0, Range(1, 10)))), should.Equal, []int{1, 3, 6, 10, 15})
@@ -237,6 +622,59 @@ func TestRest(t *testing.T) {
 	should.So(t, Slice(Take(3, Rest(Range(1, 10)))), should.Equal, Slice(Range(2, 5)))
 	should.So(t, Slice(Take(3, Rest(Range(0, 0)))), should.Equal, _nil)
 }
+func TestScan(t *testing.T) {
+	lengths := func(total int, s string) int { return total + len(s) }
+	should.So(t, Slice(Scan(lengths, 0, once(Variadic("a", "bb", "ccc")))), should.Equal, []int{1, 3, 6})
+	should.So(t, Slice(Take(3, Scan(func(a string, n int) string { return a + strconv.Itoa(n) }, "", RangeOpen(0, 1)))), should.Equal, []string{"0", "01", "012"})
+	should.So(t, Slice(Scan(lengths, 0, Variadic[string]())), should.Equal, _nil)
+}
+func TestUnzip(t *testing.T) {
+	as, bs := Unzip(once(ZipPairs(Range(0, 4), Variadic("a", "b", "c", "d"))))
+	should.So(t, Slice(as), should.Equal, _0123)
+	should.So(t, Slice(bs), should.Equal, []string{"a", "b", "c", "d"})
+	should.So(t, func() { Slice(as) }, should.Panic)
+	should.So(t, func() { Slice(bs) }, should.Panic)
+
+	var started, stopped bool
+	source := func(yield func(Pair[int, int]) bool) {
+		started = true
+		defer func() { stopped = true }()
+		for n := 0; yield(Pair[int, int]{A: n, B: -n}); n++ {
+		}
+	}
+	xs, ys := Unzip(iter.Seq[Pair[int, int]](source))
+	should.So(t, started, should.BeFalse)
+	should.So(t, Slice(Take(2, ys)), should.Equal, []int{0, -1})
+	should.So(t, started, should.BeTrue)
+	should.So(t, stopped, should.BeFalse)
+	for range xs {
+		break
+	}
+	should.So(t, stopped, should.BeTrue)
+}
+func TestUnzipLockstep(t *testing.T) {
+	pulled := 0
+	source := Map(func(n int) Pair[int, int] { pulled++; return Pair[int, int]{A: n, B: -n} }, RangeOpen(0, 1))
+	as, bs := Unzip(source)
+	should.So(t, Slice(Take(3, Map2(op.Add[int], as, bs))), should.Equal, []int{0, 0, 0})
+	should.So(t, pulled, should.Equal, 3)
+
+	as, bs = Unzip(ZipPairs(Range(0, 5), Range(10, 15)))
+	should.So(t, Slice(Take(2, bs)), should.Equal, []int{10, 11})
+	should.So(t, Slice(as), should.Equal, []int{0, 1, 2, 3, 4})
+}
+func TestZip3(t *testing.T) {
+	should.So(t, Slice(Zip3(Range(0, 2), Variadic("a", "b", "c"), Variadic(true, false))), should.Equal, []Triple[int, string, bool]{
+		{A: 0, B: "a", C: true},
+		{A: 1, B: "b", C: false},
+	})
+}
+func TestZipLongest(t *testing.T) {
+	should.So(t, Slice(ZipLongest(-1, "?", Range(0, 3), Variadic("a"))), should.Equal, []Pair[int, string]{{0, "a"}, {1, "?"}, {2, "?"}})
+	should.So(t, Slice(ZipLongest(-1, "?", Range(0, 1), Variadic("a", "b"))), should.Equal, []Pair[int, string]{{0, "a"}, {-1, "b"}})
+	should.So(t, Slice(ZipLongest(-1, "?", Range(0, 0), Variadic[string]())), should.BeEmpty)
+	should.So(t, Slice(Take(2, ZipLongest(-1, -1, RangeOpen(0, 1), Range(5, 6)))), should.Equal, []Pair[int, int]{{0, 5}, {1, -1}})
+}
 func TestZipMap(t *testing.T) {
 	should.So(t, ZipMap(Range(0, 5), Range(10, 15)), should.Equal, map[int]int{
 		0: 10,
@@ -260,8 +698,108 @@ func TestZipMap(t *testing.T) {
 		4: 14,
 	})
 }
+func TestZipN(t *testing.T) {
+	should.So(t, Slice(ZipN(Range(0, 3), Range(10, 12), Range(20, 25))), should.Equal, [][]int{{0, 10, 20}, {1, 11, 21}})
+	should.So(t, Slice(ZipN[int]()), should.BeEmpty)
+	should.So(t, Slice(Take(1, ZipN(RangeOpen(0, 1)))), should.Equal, [][]int{{0}})
+	rows := Slice(ZipN(Range(0, 2), Range(0, 2)))
+	rows[0][0] = 42
+	should.So(t, rows[1], should.Equal, []int{1, 1})
+}
 func TestZipPairs(t *testing.T) {
 	should.So(t, PairsMap(Take(4, ZipPairs(RangeStep(0, 10, 2), RangeStep(1, 11, 2)))), should.Equal, map[int]int{0: 1, 2: 3, 4: 5, 6: 7})
 	should.So(t, PairsMap(ZipPairs(RangeStep(0, 10, 2), RangeStep(1, 9, 2))), should.Equal, map[int]int{0: 1, 2: 3, 4: 5, 6: 7})
 	should.So(t, PairsMap(ZipPairs(RangeStep(0, 8, 2), RangeStep(1, 11, 2))), should.Equal, map[int]int{0: 1, 2: 3, 4: 5, 6: 7})
 }
+
+// collectErr gathers values until the first error, invoking each
+// observer with the index of every value as it arrives.
+func collectErr[V any](seq iter.Seq2[V, error], observers ...func(int)) (values []V, err error) {
+	for v, err := range seq {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+		for _, observe := range observers {
+			observe(len(values) - 1)
+		}
+	}
+	return values, nil
+}
+func cancelAt(n int, cancel context.CancelCauseFunc, cause error) func(int) {
+	return func(i int) {
+		if i == n {
+			cancel(cause)
+		}
+	}
+}
+
+// once wraps seq so that iterating it a second time fails loudly,
+// exposing any function that walks its input more than once.
+func once[V any](seq iter.Seq[V]) iter.Seq[V] {
+	iterated := false
+	return func(yield func(V) bool) {
+		if iterated {
+			panic("sequence iterated more than once")
+		}
+		iterated = true
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// gatedSquare returns a squaring func whose calls block until limit of them are
+// running at once (proving that much concurrency without sleeping), along with
+// the peak number of concurrent calls observed.
+func gatedSquare(limit int32) (func(int) int, *atomic.Int32) {
+	var active, peak atomic.Int32
+	gate := make(chan struct{})
+	var open sync.Once
+	return func(n int) int {
+		now := active.Add(1)
+		defer active.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		if now >= limit {
+			open.Do(func() { close(gate) })
+		}
+		<-gate
+		return n * n
+	}, &peak
+}
+
+// goroutinesSettle reports whether the number of goroutines drops to (at most) n
+// before a deadline, allowing goroutines that are on their way out (eg. having
+// already signaled a WaitGroup) to finish exiting.
+func goroutinesSettle(n int) bool {
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+// returnsPromptly reports whether f returns before a deadline (leaving it running if not).
+func returnsPromptly(f func()) bool {
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		f()
+	}()
+	select {
+	case <-returned:
+		return true
+	case <-time.After(500 * time.Millisecond):
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+// Package ring implements a fixed-capacity, generic ring buffer.
+package ring
+
+import "iter"
+
+// Policy determines what Push does when the buffer is full.
+type Policy int
+
+const (
+	Overwrite Policy = iota // evict the oldest (front) element to make room
+	Reject                  // leave the buffer unchanged and report failure
+)
+
+// Buffer holds up to Cap elements in insertion order, from the front (oldest)
+// to the back (newest). The zero value is a buffer with no capacity.
+type Buffer[T any] struct {
+	items  []T
+	head   int
+	length int
+	policy Policy
+}
+
+func New[T any](capacity int, policy Policy) *Buffer[T] {
+	return &Buffer[T]{items: make([]T, max(capacity, 0)), policy: policy}
+}
+
+// Push appends t to the back of the buffer, reporting false if t was not stored
+// (because the buffer is full and its policy is Reject, or it has no capacity).
+func (this *Buffer[T]) Push(t T) bool {
+	if this.Cap() == 0 {
+		return false
+	}
+	if this.Full() {
+		if this.policy == Reject {
+			return false
+		}
+		this.items[this.head] = t
+		this.head = this.index(1)
+		return true
+	}
+	this.items[this.index(this.length)] = t
+	this.length++
+	return true
+}
+
+// Pop removes and returns the front (oldest) element.
+func (this *Buffer[T]) Pop() (result T, ok bool) {
+	if this.length == 0 {
+		return result, false
+	}
+	var zero T
+	result, this.items[this.head] = this.items[this.head], zero
+	this.head = this.index(1)
+	this.length--
+	return result, true
+}
+func (this *Buffer[T]) PeekFront() (T, bool) { return this.At(0) }
+func (this *Buffer[T]) PeekBack() (T, bool)  { return this.At(this.length - 1) }
+
+// At returns the element i positions from the front.
+func (this *Buffer[T]) At(i int) (result T, ok bool) {
+	if i < 0 || i >= this.length {
+		return result, false
+	}
+	return this.items[this.index(i)], true
+}
+func (this *Buffer[T]) Len() int   { return this.length }
+func (this *Buffer[T]) Cap() int   { return len(this.items) }
+func (this *Buffer[T]) Full() bool { return this.length == this.Cap() }
+func (this *Buffer[T]) Reset() {
+	clear(this.items)
+	this.head, this.length = 0, 0
+}
+
+// All yields the elements from front (oldest) to back (newest).
+func (this *Buffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < this.length; i++ {
+			if !yield(this.items[this.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields the elements from back (newest) to front (oldest).
+func (this *Buffer[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := this.length - 1; i >= 0; i-- {
+			if !yield(this.items[this.index(i)]) {
+				return
+			}
+		}
+	}
+}
+func (this *Buffer[T]) index(offset int) int {
+	return (this.head + offset) % len(this.items)
+}
@@ -0,0 +1,95 @@
+package ring_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/ring"
+)
+
+func TestOverwrite(t *testing.T) {
+	buffer := ring.New[int](3, ring.Overwrite)
+	should.So(t, buffer.Cap(), should.Equal, 3)
+	should.So(t, buffer.Len(), should.Equal, 0)
+	should.So(t, buffer.Full(), should.BeFalse)
+	for n := range 5 {
+		should.So(t, buffer.Push(n), should.BeTrue)
+	}
+	should.So(t, buffer.Full(), should.BeTrue)
+	should.So(t, buffer.Len(), should.Equal, 3)
+	should.So(t, slices.Collect(buffer.All()), should.Equal, []int{2, 3, 4})
+	should.So(t, slices.Collect(buffer.Backward()), should.Equal, []int{4, 3, 2})
+
+	front, ok := buffer.PeekFront()
+	should.So(t, front, should.Equal, 2)
+	should.So(t, ok, should.BeTrue)
+	back, ok := buffer.PeekBack()
+	should.So(t, back, should.Equal, 4)
+	should.So(t, ok, should.BeTrue)
+	middle, ok := buffer.At(1)
+	should.So(t, middle, should.Equal, 3)
+	should.So(t, ok, should.BeTrue)
+	_, ok = buffer.At(3)
+	should.So(t, ok, should.BeFalse)
+	_, ok = buffer.At(-1)
+	should.So(t, ok, should.BeFalse)
+}
+func TestReject(t *testing.T) {
+	buffer := ring.New[string](2, ring.Reject)
+	should.So(t, buffer.Push("a"), should.BeTrue)
+	should.So(t, buffer.Push("b"), should.BeTrue)
+	should.So(t, buffer.Push("c"), should.BeFalse)
+	should.So(t, slices.Collect(buffer.All()), should.Equal, []string{"a", "b"})
+	popped, ok := buffer.Pop()
+	should.So(t, popped, should.Equal, "a")
+	should.So(t, ok, should.BeTrue)
+	should.So(t, buffer.Push("c"), should.BeTrue)
+	should.So(t, slices.Collect(buffer.All()), should.Equal, []string{"b", "c"})
+}
+func TestPopAndReset(t *testing.T) {
+	buffer := ring.New[int](3, ring.Overwrite)
+	_, ok := buffer.Pop()
+	should.So(t, ok, should.BeFalse)
+	_, ok = buffer.PeekFront()
+	should.So(t, ok, should.BeFalse)
+	_, ok = buffer.PeekBack()
+	should.So(t, ok, should.BeFalse)
+	for n := range 4 {
+		buffer.Push(n)
+	}
+	for _, expected := range []int{1, 2, 3} {
+		popped, ok := buffer.Pop()
+		should.So(t, popped, should.Equal, expected)
+		should.So(t, ok, should.BeTrue)
+	}
+	should.So(t, buffer.Len(), should.Equal, 0)
+	buffer.Push(42)
+	buffer.Reset()
+	should.So(t, buffer.Len(), should.Equal, 0)
+	should.So(t, slices.Collect(buffer.All()), should.BeEmpty)
+	buffer.Push(7)
+	should.So(t, slices.Collect(buffer.All()), should.Equal, []int{7})
+}
+func TestEarlyStop(t *testing.T) {
+	buffer := ring.New[int](3, ring.Overwrite)
+	for n := range 3 {
+		buffer.Push(n)
+	}
+	for n := range buffer.All() {
+		should.So(t, n, should.Equal, 0)
+		break
+	}
+	for n := range buffer.Backward() {
+		should.So(t, n, should.Equal, 2)
+		break
+	}
+}
+func TestNoCapacity(t *testing.T) {
+	for _, buffer := range []*ring.Buffer[int]{ring.New[int](0, ring.Overwrite), ring.New[int](-1, ring.Reject), new(ring.Buffer[int])} {
+		should.So(t, buffer.Push(1), should.BeFalse)
+		should.So(t, buffer.Len(), should.Equal, 0)
+		should.So(t, buffer.Full(), should.BeTrue)
+		should.So(t, slices.Collect(buffer.All()), should.BeEmpty)
+	}
+}
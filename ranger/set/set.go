@@ -0,0 +1,143 @@
+// Package set provides a map-backed Set type and streaming set operations over sequences.
+package set
+
+import "iter"
+
+type Set[T comparable] map[T]struct{}
+
+func New[T comparable](items ...T) Set[T] {
+	result := make(Set[T], len(items))
+	result.Add(items...)
+	return result
+}
+func From[T comparable](seq iter.Seq[T]) Set[T] {
+	result := make(Set[T])
+	for item := range seq {
+		result[item] = struct{}{}
+	}
+	return result
+}
+
+func (this Set[T]) Add(items ...T) {
+	for _, item := range items {
+		this[item] = struct{}{}
+	}
+}
+func (this Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(this, item)
+	}
+}
+func (this Set[T]) Has(item T) bool {
+	_, ok := this[item]
+	return ok
+}
+func (this Set[T]) Len() int {
+	return len(this)
+}
+func (this Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range this {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+func (this Set[T]) Equal(that Set[T]) bool {
+	return this.Len() == that.Len() && this.Subset(that)
+}
+func (this Set[T]) Subset(that Set[T]) bool {
+	if this.Len() > that.Len() {
+		return false
+	}
+	for item := range this {
+		if !that.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Difference lazily yields the distinct elements of a that are not in b, in
+// first-seen order; only b is materialized (along with whatever has been yielded).
+func Difference[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		excluded := From(b)
+		for item := range a {
+			if excluded.Has(item) {
+				continue
+			}
+			excluded.Add(item)
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Intersect lazily yields the distinct elements of a that are also in b, in
+// first-seen order; only b is materialized.
+func Intersect[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		remaining := From(b)
+		for item := range a {
+			if !remaining.Has(item) {
+				continue
+			}
+			remaining.Remove(item)
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SymmetricDifference lazily yields the distinct elements found in exactly one of a
+// and b: first those from a (in order), then those from b (in order). b is materialized.
+func SymmetricDifference[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var order []T
+		others := make(Set[T])
+		for item := range b {
+			if !others.Has(item) {
+				others.Add(item)
+				order = append(order, item)
+			}
+		}
+		seen := make(Set[T])
+		for item := range a {
+			if seen.Has(item) {
+				continue
+			}
+			seen.Add(item)
+			if !others.Has(item) && !yield(item) {
+				return
+			}
+		}
+		for _, item := range order {
+			if !seen.Has(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Union lazily yields the distinct elements of a followed by those of b, in
+// first-seen order; only the elements yielded so far are retained.
+func Union[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(Set[T])
+		for _, seq := range []iter.Seq[T]{a, b} {
+			for item := range seq {
+				if seen.Has(item) {
+					continue
+				}
+				seen.Add(item)
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/set"
+)
+
+func TestSet(t *testing.T) {
+	s := set.New(1, 2, 3)
+	should.So(t, s.Len(), should.Equal, 3)
+	should.So(t, s.Has(2), should.BeTrue)
+	s.Remove(2, 4)
+	should.So(t, s.Has(2), should.BeFalse)
+	s.Add(5, 5)
+	should.So(t, s.Len(), should.Equal, 3)
+	should.So(t, slices.Sorted(s.All()), should.Equal, []int{1, 3, 5})
+	should.So(t, ranger.Count(ranger.Take(2, s.All())), should.Equal, 2)
+
+	should.So(t, s.Equal(set.From(ranger.Variadic(5, 3, 1, 1))), should.BeTrue)
+	should.So(t, s.Equal(set.New(1, 3)), should.BeFalse)
+	should.So(t, s.Equal(set.New(1, 3, 4)), should.BeFalse)
+	should.So(t, set.New(1, 3).Subset(s), should.BeTrue)
+	should.So(t, s.Subset(s), should.BeTrue)
+	should.So(t, set.New(1, 4).Subset(s), should.BeFalse)
+	should.So(t, s.Subset(set.New(1, 3)), should.BeFalse)
+	should.So(t, set.New[int]().Subset(s), should.BeTrue)
+}
+func TestUnion(t *testing.T) {
+	should.So(t, ranger.Slice(set.Union(ranger.Variadic(3, 1, 3), ranger.Variadic(2, 1, 4))), should.Equal, []int{3, 1, 2, 4})
+	should.So(t, ranger.Slice(ranger.Take(2, set.Union(ranger.RangeOpen(0, 1), ranger.RangeOpen(0, 1)))), should.Equal, []int{0, 1})
+}
+func TestIntersect(t *testing.T) {
+	should.So(t, ranger.Slice(set.Intersect(ranger.Variadic(3, 1, 3, 5, 2), ranger.Variadic(2, 3, 4))), should.Equal, []int{3, 2})
+	should.So(t, ranger.Slice(ranger.Take(2, set.Intersect(ranger.RangeOpen(0, 1), ranger.Range(5, 10)))), should.Equal, []int{5, 6})
+}
+func TestDifference(t *testing.T) {
+	should.So(t, ranger.Slice(set.Difference(ranger.Variadic(3, 1, 3, 5, 2), ranger.Variadic(2, 4))), should.Equal, []int{3, 1, 5})
+	should.So(t, ranger.Slice(ranger.Take(2, set.Difference(ranger.RangeOpen(0, 1), ranger.Variadic(0, 2)))), should.Equal, []int{1, 3})
+}
+func TestSymmetricDifference(t *testing.T) {
+	should.So(t, ranger.Slice(set.SymmetricDifference(ranger.Variadic(1, 2, 2, 3), ranger.Variadic(4, 3, 5, 4))), should.Equal, []int{1, 2, 4, 5})
+	should.So(t, ranger.Slice(ranger.Take(1, set.SymmetricDifference(ranger.Variadic(1, 2), ranger.Variadic(2)))), should.Equal, []int{1})
+	should.So(t, ranger.Slice(ranger.Take(2, set.SymmetricDifference(ranger.Variadic(1, 2), ranger.Variadic(2, 3, 4)))), should.Equal, []int{1, 3})
+}
@@ -0,0 +1,123 @@
+package ranger
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// The Sorted* set operations below walk two sequences, each sorted ascending
+// according to compare, in lockstep, holding only the current element of each.
+// Duplicates are treated as a multiset: an element occurring m times in a and n
+// times in b is yielded max(m, n) times by SortedUnion, min(m, n) times by
+// SortedIntersect and max(m-n, 0) times by SortedDifference. The results are
+// unspecified for inputs that are not sorted; wrap inputs with AssertSorted or
+// CheckSorted to detect that.
+
+func SortedDifference[V any](compare func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		sortedWalk(compare, a, b, yield, nil, nil)
+	}
+}
+func SortedIntersect[V any](compare func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		sortedWalk(compare, a, b, nil, nil, yield)
+	}
+}
+func SortedUnion[V any](compare func(a, b V) int, a, b iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		sortedWalk(compare, a, b, yield, yield, yield)
+	}
+}
+
+// AssertSorted passes seq through unchanged, panicking with an UnsortedError as
+// soon as an element compares less than its predecessor.
+func AssertSorted[V any](compare func(a, b V) int, seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v, err := range CheckSorted(compare, seq) {
+			if err != nil {
+				panic(err)
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// CheckSorted passes seq through unchanged (with nil errors), ending with an
+// UnsortedError in place of the first element that compares less than its predecessor.
+func CheckSorted[V any](compare func(a, b V) int, seq iter.Seq[V]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		var previous V
+		index := 0
+		for v := range seq {
+			if index > 0 && compare(previous, v) > 0 {
+				var zero V
+				yield(zero, UnsortedError{Index: index})
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+			previous = v
+			index++
+		}
+	}
+}
+
+// ErrUnsorted is matched (via errors.Is) by the errors that AssertSorted panics with
+// and CheckSorted yields.
+var ErrUnsorted = errors.New("sequence not sorted")
+
+type UnsortedError struct {
+	Index int // the position of the first element that compared less than its predecessor
+}
+
+func (this UnsortedError) Error() string {
+	return fmt.Sprintf("sequence not sorted: element %d is less than its predecessor", this.Index)
+}
+func (this UnsortedError) Unwrap() error { return ErrUnsorted }
+
+// sortedWalk merges a and b, passing elements found only in a to onlyA, elements
+// found only in b to onlyB, and elements (from a) found in both to both. Any of the
+// callbacks may be nil, in which case the corresponding elements are skipped.
+func sortedWalk[V any](compare func(a, b V) int, a, b iter.Seq[V], onlyA, onlyB, both func(V) bool) {
+	nextA, stopA := iter.Pull(a)
+	defer stopA()
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+	emit := func(f func(V) bool, v V) bool { return f == nil || f(v) }
+	va, okA := nextA()
+	vb, okB := nextB()
+	for okA && okB {
+		switch c := compare(va, vb); {
+		case c < 0:
+			if !emit(onlyA, va) {
+				return
+			}
+			va, okA = nextA()
+		case c > 0:
+			if !emit(onlyB, vb) {
+				return
+			}
+			vb, okB = nextB()
+		default:
+			if !emit(both, va) {
+				return
+			}
+			va, okA = nextA()
+			vb, okB = nextB()
+		}
+	}
+	for ; okA && onlyA != nil; va, okA = nextA() {
+		if !onlyA(va) {
+			return
+		}
+	}
+	for ; okB && onlyB != nil; vb, okB = nextB() {
+		if !onlyB(vb) {
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package ranger
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+)
+
+var (
+	sortedA = []int{1, 2, 2, 2, 4, 6, 7}
+	sortedB = []int{2, 2, 3, 4, 8}
+)
+
+func TestSortedUnion(t *testing.T) {
+	union := SortedUnion(cmp.Compare[int], once(Iterator(sortedA)), once(Iterator(sortedB)))
+	should.So(t, Slice(union), should.Equal, []int{1, 2, 2, 2, 3, 4, 6, 7, 8})
+	should.So(t, Slice(SortedUnion(cmp.Compare[int], Variadic[int](), Iterator(sortedB))), should.Equal, sortedB)
+	should.So(t, Slice(Take(3, SortedUnion(cmp.Compare[int], RangeOpen(0, 2), RangeOpen(0, 3)))), should.Equal, []int{0, 2, 3})
+}
+func TestSortedIntersect(t *testing.T) {
+	intersection := SortedIntersect(cmp.Compare[int], once(Iterator(sortedA)), once(Iterator(sortedB)))
+	should.So(t, Slice(intersection), should.Equal, []int{2, 2, 4})
+	should.So(t, Slice(SortedIntersect(cmp.Compare[int], Iterator(sortedA), Variadic[int]())), should.BeEmpty)
+	should.So(t, Slice(Take(3, SortedIntersect(cmp.Compare[int], RangeOpen(0, 2), RangeOpen(0, 3)))), should.Equal, []int{0, 6, 12})
+}
+func TestSortedDifference(t *testing.T) {
+	should.So(t, Slice(SortedDifference(cmp.Compare[int], once(Iterator(sortedA)), once(Iterator(sortedB)))), should.Equal, []int{1, 2, 6, 7})
+	should.So(t, Slice(SortedDifference(cmp.Compare[int], Iterator(sortedB), Iterator(sortedA))), should.Equal, []int{3, 8})
+	should.So(t, Slice(Take(2, SortedDifference(cmp.Compare[int], RangeOpen(0, 1), RangeOpen(0, 2)))), should.Equal, []int{1, 3})
+}
+func TestAssertSorted(t *testing.T) {
+	should.So(t, Slice(AssertSorted(cmp.Compare[int], Iterator(sortedA))), should.Equal, sortedA)
+	should.So(t, Slice(Take(2, AssertSorted(cmp.Compare[int], Variadic(1, 2, 0)))), should.Equal, []int{1, 2})
+	should.So(t, func() { Slice(AssertSorted(cmp.Compare[int], Variadic(1, 2, 0))) }, should.Panic)
+	should.So(t, func() {
+		Slice(SortedUnion(cmp.Compare[int], AssertSorted(cmp.Compare[int], Variadic(3, 1)), Variadic(2)))
+	}, should.Panic)
+}
+func TestCheckSorted(t *testing.T) {
+	values, err := collectErr(CheckSorted(cmp.Compare[int], Variadic(1, 1, 3, 2, 4)))
+	should.So(t, values, should.Equal, []int{1, 1, 3})
+	should.So(t, errors.Is(err, ErrUnsorted), should.BeTrue)
+	should.So(t, err, should.Equal, error(UnsortedError{Index: 3}))
+
+	values, err = collectErr(CheckSorted(cmp.Compare[int], Iterator(sortedB)))
+	should.So(t, values, should.Equal, sortedB)
+	should.So(t, err, should.BeNil)
+}
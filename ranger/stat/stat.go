@@ -0,0 +1,249 @@
+// Package stat provides descriptive statistics over numeric sequences.
+//
+// Unless noted otherwise, results are float64 and follow the conventions of the math
+// package: an empty input (or one too small for the statistic, like the sample variance
+// of a single value) yields NaN, and any NaN in the input propagates to the result.
+package stat
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/mdw-go/funcy/ranger/is"
+)
+
+func Mean[N is.Number](seq iter.Seq[N]) float64 {
+	count, mean, _ := welford(seq)
+	if count == 0 {
+		return math.NaN()
+	}
+	return mean
+}
+func Median[N is.Number](seq iter.Seq[N]) float64 {
+	return Percentile(50, Linear, seq)
+}
+
+// Mode returns the most frequent value(s) in ascending order (nil for empty input).
+// NaN values are never equal to one another and so are ignored.
+func Mode[N is.Number](seq iter.Seq[N]) (result []N) {
+	counts := make(map[N]int)
+	most := 0
+	for n := range seq {
+		if n != n {
+			continue
+		}
+		counts[n]++
+		most = max(most, counts[n])
+	}
+	for n, count := range counts {
+		if count == most {
+			result = append(result, n)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+func PopulationVariance[N is.Number](seq iter.Seq[N]) float64 {
+	count, _, m2 := welford(seq)
+	if count == 0 {
+		return math.NaN()
+	}
+	return m2 / float64(count)
+}
+func SampleVariance[N is.Number](seq iter.Seq[N]) float64 {
+	count, _, m2 := welford(seq)
+	if count < 2 {
+		return math.NaN()
+	}
+	return m2 / float64(count-1)
+}
+func PopulationStdDev[N is.Number](seq iter.Seq[N]) float64 {
+	return math.Sqrt(PopulationVariance(seq))
+}
+func SampleStdDev[N is.Number](seq iter.Seq[N]) float64 {
+	return math.Sqrt(SampleVariance(seq))
+}
+
+// Extent finds the minimum and maximum (and the range between them) in a single pass.
+// The spread is computed in float64, so it cannot overflow a narrow integer type.
+// ok is false for empty input; a NaN in the input is returned as all three results.
+func Extent[N is.Number](seq iter.Seq[N]) (min, max N, spread float64, ok bool) {
+	for n := range seq {
+		if n != n {
+			return n, n, float64(n), true
+		}
+		if !ok || n < min {
+			min = n
+		}
+		if !ok || n > max {
+			max = n
+		}
+		ok = true
+	}
+	return min, max, float64(max) - float64(min), ok
+}
+
+// Interpolation selects how Percentile and Quantiles resolve a rank that falls
+// between two adjacent (sorted) values, following the conventions of numpy.
+type Interpolation int
+
+const (
+	Linear Interpolation = iota
+	Lower
+	Higher
+	Nearest
+	Midpoint
+)
+
+// Percentile computes the p-th percentile (0 <= p <= 100) of the values in seq.
+// An out of range p yields NaN.
+func Percentile[N is.Number](p float64, interpolation Interpolation, seq iter.Seq[N]) float64 {
+	return Quantiles([]float64{p / 100}, interpolation, seq)[0]
+}
+
+// Quantiles computes each of the quantiles qs (0 <= q <= 1) after sorting seq just once.
+func Quantiles[N is.Number](qs []float64, interpolation Interpolation, seq iter.Seq[N]) []float64 {
+	values, hasNaN := floats(seq)
+	slices.Sort(values)
+	result := make([]float64, len(qs))
+	for x, q := range qs {
+		if hasNaN || len(values) == 0 || q != q || q < 0 || q > 1 {
+			result[x] = math.NaN()
+			continue
+		}
+		rank := q * float64(len(values)-1)
+		lower, upper := values[int(math.Floor(rank))], values[int(math.Ceil(rank))]
+		fraction := rank - math.Floor(rank)
+		switch interpolation {
+		case Lower:
+			result[x] = lower
+		case Higher:
+			result[x] = upper
+		case Nearest:
+			result[x] = values[int(math.RoundToEven(rank))]
+		case Midpoint:
+			result[x] = (lower + upper) / 2
+		default:
+			result[x] = lower + (upper-lower)*fraction
+		}
+	}
+	return result
+}
+
+type Bin struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+// Binning produces the (ascending) bin edges for a histogram of values spanning [min, max].
+type Binning func(min, max float64) (edges []float64)
+
+// EqualWidth divides the span of the input into n bins of equal width.
+func EqualWidth(n int) Binning {
+	return func(min, max float64) (edges []float64) {
+		if n <= 0 {
+			return nil
+		}
+		if min == max {
+			return []float64{min, max}
+		}
+		width := (max - min) / float64(n)
+		for x := range n {
+			edges = append(edges, min+float64(x)*width)
+		}
+		return append(edges, max)
+	}
+}
+
+// Edges uses the provided (ascending) edges regardless of the input.
+func Edges(edges ...float64) Binning {
+	return func(_, _ float64) []float64 { return edges }
+}
+
+// Histogram counts the values of seq into the bins described by binning. Each bin covers
+// [Low, High), except the last which also includes its High edge. Values outside all bins
+// and NaN values are not counted. Empty input yields no bins.
+func Histogram[N is.Number](binning Binning, seq iter.Seq[N]) (result []Bin) {
+	var values []float64
+	for n := range seq {
+		if n == n {
+			values = append(values, float64(n))
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	edges := binning(slices.Min(values), slices.Max(values))
+	for x := 1; x < len(edges); x++ {
+		result = append(result, Bin{Low: edges[x-1], High: edges[x]})
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	last := len(result) - 1
+	for _, v := range values {
+		if v == result[last].High {
+			result[last].Count++
+			continue
+		}
+		x, found := slices.BinarySearchFunc(result, v, func(bin Bin, v float64) int {
+			switch {
+			case v < bin.Low:
+				return 1
+			case v >= bin.High:
+				return -1
+			default:
+				return 0
+			}
+		})
+		if found {
+			result[x].Count++
+		}
+	}
+	return result
+}
+
+func floats[N is.Number](seq iter.Seq[N]) (result []float64, hasNaN bool) {
+	for n := range seq {
+		hasNaN = hasNaN || n != n
+		result = append(result, float64(n))
+	}
+	return result, hasNaN
+}
+
+// welford accumulates the count, mean and sum of squared deviations in a single,
+// numerically stable pass (Welford's algorithm). Infinities are counted rather than
+// accumulated (which would compute Inf-Inf), and then dominate the mean and leave
+// the deviations (and so any variance) undefined.
+func welford[N is.Number](seq iter.Seq[N]) (count int, mean, m2 float64) {
+	var finite int
+	var nan, positive, negative bool
+	for n := range seq {
+		count++
+		switch f := float64(n); {
+		case math.IsNaN(f):
+			nan = true
+		case math.IsInf(f, 1):
+			positive = true
+		case math.IsInf(f, -1):
+			negative = true
+		default:
+			finite++
+			delta := f - mean
+			mean += delta / float64(finite)
+			m2 += delta * (f - mean)
+		}
+	}
+	switch {
+	case nan || (positive && negative):
+		return count, math.NaN(), math.NaN()
+	case positive:
+		return count, math.Inf(1), math.NaN()
+	case negative:
+		return count, math.Inf(-1), math.NaN()
+	default:
+		return count, mean, m2
+	}
+}
@@ -0,0 +1,95 @@
+package stat_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/stat"
+)
+
+var (
+	empty = ranger.Range(0, 0)
+	nan   = ranger.Variadic(1, math.NaN(), 3)
+	data  = ranger.Variadic(2, 4, 4, 4, 5, 5, 7, 9)
+)
+
+func TestMean(t *testing.T) {
+	should.So(t, stat.Mean(data), should.Equal, 5.0)
+	should.So(t, stat.Mean(ranger.Variadic[uint8](255, 255)), should.Equal, 255.0)
+	should.So(t, math.IsNaN(stat.Mean(empty)), should.BeTrue)
+	should.So(t, math.IsNaN(stat.Mean(nan)), should.BeTrue)
+}
+func TestMedian(t *testing.T) {
+	should.So(t, stat.Median(data), should.Equal, 4.5)
+	should.So(t, stat.Median(ranger.Variadic(3, 1, 2)), should.Equal, 2.0)
+	should.So(t, math.IsNaN(stat.Median(empty)), should.BeTrue)
+	should.So(t, math.IsNaN(stat.Median(nan)), should.BeTrue)
+}
+func TestMode(t *testing.T) {
+	should.So(t, stat.Mode(data), should.Equal, []int{4})
+	should.So(t, stat.Mode(ranger.Variadic(3, 1, 3, 1, 2)), should.Equal, []int{1, 3})
+	should.So(t, stat.Mode(empty), should.BeNil)
+	should.So(t, stat.Mode(ranger.Variadic(math.NaN(), math.NaN(), 1.5)), should.Equal, []float64{1.5})
+}
+func TestMeanNonFinite(t *testing.T) {
+	inf := math.Inf(1)
+	should.So(t, stat.Mean(ranger.Variadic(inf, 1)), should.Equal, inf)
+	should.So(t, stat.Mean(ranger.Variadic(inf, inf)), should.Equal, inf)
+	should.So(t, stat.Mean(ranger.Variadic(1, -inf)), should.Equal, -inf)
+	should.So(t, math.IsNaN(stat.Mean(ranger.Variadic(inf, -inf))), should.BeTrue)
+	should.So(t, math.IsNaN(stat.Mean(ranger.Variadic(inf, math.NaN()))), should.BeTrue)
+	should.So(t, math.IsNaN(stat.PopulationVariance(ranger.Variadic(inf, 1))), should.BeTrue)
+	should.So(t, math.IsNaN(stat.SampleStdDev(ranger.Variadic(1, 2, -inf))), should.BeTrue)
+}
+func TestVariance(t *testing.T) {
+	should.So(t, stat.PopulationVariance(data), should.Equal, 4.0)
+	should.So(t, stat.PopulationStdDev(data), should.Equal, 2.0)
+	should.So(t, stat.SampleVariance(data), should.Equal, 32.0/7)
+	should.So(t, stat.SampleStdDev(data), should.Equal, math.Sqrt(32.0/7))
+	should.So(t, stat.PopulationVariance(ranger.Variadic(42)), should.Equal, 0.0)
+	should.So(t, math.IsNaN(stat.SampleVariance(ranger.Variadic(42))), should.BeTrue)
+	should.So(t, math.IsNaN(stat.PopulationVariance(empty)), should.BeTrue)
+	should.So(t, math.IsNaN(stat.SampleStdDev(nan)), should.BeTrue)
+}
+func TestExtent(t *testing.T) {
+	low, high, spread, ok := stat.Extent(ranger.Variadic(3, -2, 7, 1))
+	should.So(t, []int{low, high}, should.Equal, []int{-2, 7})
+	should.So(t, spread, should.Equal, 9.0)
+	should.So(t, ok, should.BeTrue)
+	narrowLow, narrowHigh, spread, _ := stat.Extent(ranger.Variadic[int8](-100, 100))
+	should.So(t, []int8{narrowLow, narrowHigh}, should.Equal, []int8{-100, 100})
+	should.So(t, spread, should.Equal, 200.0)
+	_, _, _, ok = stat.Extent(empty)
+	should.So(t, ok, should.BeFalse)
+	lowest, _, _, ok := stat.Extent(nan)
+	should.So(t, math.IsNaN(lowest), should.BeTrue)
+	should.So(t, ok, should.BeTrue)
+}
+func TestPercentile(t *testing.T) {
+	values := ranger.Variadic(1, 2, 3, 4)
+	should.So(t, stat.Percentile(0, stat.Linear, values), should.Equal, 1.0)
+	should.So(t, stat.Percentile(100, stat.Linear, values), should.Equal, 4.0)
+	should.So(t, stat.Percentile(40, stat.Linear, values), should.Equal, 2.2)
+	should.So(t, stat.Percentile(40, stat.Lower, values), should.Equal, 2.0)
+	should.So(t, stat.Percentile(40, stat.Higher, values), should.Equal, 3.0)
+	should.So(t, stat.Percentile(40, stat.Nearest, values), should.Equal, 2.0)
+	should.So(t, stat.Percentile(40, stat.Midpoint, values), should.Equal, 2.5)
+	should.So(t, math.IsNaN(stat.Percentile(101, stat.Linear, values)), should.BeTrue)
+	should.So(t, math.IsNaN(stat.Percentile(50, stat.Linear, empty)), should.BeTrue)
+	should.So(t, stat.Quantiles([]float64{0.25, 0.5, 0.75}, stat.Linear, ranger.Range(0, 101)), should.Equal, []float64{25, 50, 75})
+}
+func TestHistogram(t *testing.T) {
+	should.So(t, stat.Histogram(stat.EqualWidth(2), ranger.Variadic(0, 1, 2, 3, 4)), should.Equal, []stat.Bin{
+		{Low: 0, High: 2, Count: 2},
+		{Low: 2, High: 4, Count: 3},
+	})
+	should.So(t, stat.Histogram(stat.Edges(0, 10, 20), ranger.Variadic(-1, 0, 5, 10, 20, 21, math.NaN())), should.Equal, []stat.Bin{
+		{Low: 0, High: 10, Count: 2},
+		{Low: 10, High: 20, Count: 2},
+	})
+	should.So(t, stat.Histogram(stat.EqualWidth(3), ranger.Variadic(5, 5)), should.Equal, []stat.Bin{{Low: 5, High: 5, Count: 2}})
+	should.So(t, stat.Histogram(stat.EqualWidth(3), empty), should.BeNil)
+	should.So(t, stat.Histogram(stat.EqualWidth(0), data), should.BeNil)
+}
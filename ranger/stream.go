@@ -0,0 +1,53 @@
+package ranger
+
+import "iter"
+
+// Stream is an iter.Seq with chainable methods for stages that preserve the element type.
+// Use NewStream (or a plain conversion) to enter a chain, Seq to leave it, and StreamMap
+// for stages that change the element type.
+type Stream[V any] iter.Seq[V]
+
+func NewStream[V any](seq iter.Seq[V]) Stream[V] { return Stream[V](seq) }
+
+func StreamMap[I, O any](f func(I) O, s Stream[I]) Stream[O] {
+	return Stream[O](Map(f, s.Seq()))
+}
+
+func (this Stream[V]) Seq() iter.Seq[V] { return iter.Seq[V](this) }
+
+func (this Stream[V]) Concat(others ...iter.Seq[V]) Stream[V] {
+	return Stream[V](Concat(append([]iter.Seq[V]{this.Seq()}, others...)...))
+}
+func (this Stream[V]) Cycle() Stream[V]         { return Stream[V](Cycle(this.Seq())) }
+func (this Stream[V]) Drop(n int) Stream[V]     { return Stream[V](Drop(n, this.Seq())) }
+func (this Stream[V]) DropLast(n int) Stream[V] { return Stream[V](DropLast(n, this.Seq())) }
+func (this Stream[V]) DropWhile(pred func(V) bool) Stream[V] {
+	return Stream[V](DropWhile(pred, this.Seq()))
+}
+func (this Stream[V]) Filter(pred func(V) bool) Stream[V] { return Stream[V](Filter(pred, this.Seq())) }
+func (this Stream[V]) Interpose(sep V) Stream[V]          { return Stream[V](Interpose(sep, this.Seq())) }
+func (this Stream[V]) Map(f func(V) V) Stream[V]          { return Stream[V](Map(f, this.Seq())) }
+func (this Stream[V]) Reductions(calc func(a, b V) V, start V) Stream[V] {
+	return Stream[V](Reductions(calc, start, this.Seq()))
+}
+func (this Stream[V]) Remove(pred func(V) bool) Stream[V] { return Stream[V](Remove(pred, this.Seq())) }
+func (this Stream[V]) Rest() Stream[V]                    { return Stream[V](Rest(this.Seq())) }
+func (this Stream[V]) SortFunc(compare func(a, b V) int) Stream[V] {
+	return Stream[V](SortFunc(compare, this.Seq()))
+}
+func (this Stream[V]) Take(n int) Stream[V]     { return Stream[V](Take(n, this.Seq())) }
+func (this Stream[V]) TakeLast(n int) Stream[V] { return Stream[V](TakeLast(n, this.Seq())) }
+func (this Stream[V]) TakeWhile(pred func(V) bool) Stream[V] {
+	return Stream[V](TakeWhile(pred, this.Seq()))
+}
+
+func (this Stream[V]) Count() int                            { return Count(this.Seq()) }
+func (this Stream[V]) DoAll(f func(V))                       { DoAll(f, this.Seq()) }
+func (this Stream[V]) First() V                              { return First(this.Seq()) }
+func (this Stream[V]) FirstOk() (V, bool)                    { return FirstOk(this.Seq()) }
+func (this Stream[V]) Last() V                               { return Last(this.Seq()) }
+func (this Stream[V]) LastOk() (V, bool)                     { return LastOk(this.Seq()) }
+func (this Stream[V]) Nth(n int) V                           { return Nth(n, this.Seq()) }
+func (this Stream[V]) NthOk(n int) (V, bool)                 { return NthOk(n, this.Seq()) }
+func (this Stream[V]) Reduce(calc func(a, b V) V, start V) V { return Reduce(calc, start, this.Seq()) }
+func (this Stream[V]) Slice() []V                            { return Slice(this.Seq()) }
@@ -0,0 +1,41 @@
+package ranger
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/is"
+	"github.com/mdw-go/funcy/ranger/op"
+)
+
+func TestStream(t *testing.T) {
+	evens := NewStream(RangeOpen(0, 1)).Map(op.Square[int]).Filter(is.Even[int]).Take(10)
+	should.So(t, evens.Reduce(op.Add[int], 0), should.Equal, 1140)
+	should.So(t, evens.Count(), should.Equal, 10)
+	should.So(t, evens.First(), should.Equal, 0)
+	should.So(t, evens.Last(), should.Equal, 324)
+	should.So(t, evens.Nth(1), should.Equal, 4)
+	should.So(t, Sum(evens.Seq()), should.Equal, 1140)
+
+	s := Stream[int](Range(0, 10))
+	should.So(t, s.Drop(2).DropLast(2).Slice(), should.Equal, []int{2, 3, 4, 5, 6, 7})
+	should.So(t, s.Rest().TakeLast(2).Slice(), should.Equal, []int{8, 9})
+	should.So(t, s.DropWhile(is.Even[int]).TakeWhile(is.Odd[int]).Slice(), should.Equal, []int{1})
+	should.So(t, s.Remove(is.Even[int]).Take(3).Interpose(0).Slice(), should.Equal, []int{1, 0, 3, 0, 5})
+	should.So(t, s.Take(2).Concat(Range(7, 9)).Cycle().Take(6).Slice(), should.Equal, []int{0, 1, 7, 8, 0, 1})
+	should.So(t, s.Take(4).Reductions(op.Add[int], 0).Slice(), should.Equal, []int{0, 1, 3, 6})
+	should.So(t, s.Take(3).SortFunc(func(a, b int) int { return b - a }).Slice(), should.Equal, []int{2, 1, 0})
+	should.So(t, StreamMap(strconv.Itoa, s.Take(3)).Slice(), should.Equal, []string{"0", "1", "2"})
+
+	_, ok := s.Drop(10).FirstOk()
+	should.So(t, ok, should.BeFalse)
+	last, ok := s.LastOk()
+	should.So(t, last, should.Equal, 9)
+	_, ok = s.NthOk(10)
+	should.So(t, ok, should.BeFalse)
+
+	var all []int
+	s.Take(2).DoAll(func(n int) { all = append(all, n) })
+	should.So(t, all, should.Equal, []int{0, 1})
+}
@@ -0,0 +1,123 @@
+// Package text provides lazy sequences of the lines, words, fields, runes and
+// bytes read from an io.Reader. Input beginning with the gzip magic number is
+// decompressed transparently. Each sequence yields a nil error alongside every
+// value and, if reading fails (including when a token exceeds the maximum token
+// size), ends with the error rather than silently truncating. The reader is
+// consumed as the sequence is iterated, so each sequence is good for one pass.
+package text
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"iter"
+	"unicode/utf8"
+)
+
+// Lines yields each line of reader, stripped of its trailing "\n" or "\r\n".
+func Lines(reader io.Reader, options ...Option) iter.Seq2[string, error] {
+	return scan(reader, bufio.ScanLines, bytesToString, options)
+}
+
+// Words yields each run of non-space characters (as defined by unicode.IsSpace) of reader.
+func Words(reader io.Reader, options ...Option) iter.Seq2[string, error] {
+	return scan(reader, bufio.ScanWords, bytesToString, options)
+}
+
+// Fields yields the text of reader between occurrences of sep (which terminates,
+// rather than separates, fields: as with Lines, a trailing sep does not produce a
+// final empty field). An empty sep splits reader into its UTF-8 runes.
+func Fields(sep string, reader io.Reader, options ...Option) iter.Seq2[string, error] {
+	if sep == "" {
+		return scan(reader, bufio.ScanRunes, bytesToString, options)
+	}
+	return scan(reader, scanSeparated([]byte(sep)), bytesToString, options)
+}
+
+// Runes yields each UTF-8 encoded rune of reader (invalid encodings yield utf8.RuneError).
+func Runes(reader io.Reader, options ...Option) iter.Seq2[rune, error] {
+	return scan(reader, bufio.ScanRunes, func(token []byte) rune {
+		r, _ := utf8.DecodeRune(token)
+		return r
+	}, options)
+}
+
+// Bytes yields each byte of reader.
+func Bytes(reader io.Reader, options ...Option) iter.Seq2[byte, error] {
+	return scan(reader, bufio.ScanBytes, func(token []byte) byte { return token[0] }, options)
+}
+
+type config struct {
+	maxTokenSize int
+}
+type Option func(*config)
+type Opt struct{}
+
+var Options Opt
+
+// MaxTokenSize sets the size of the largest token (ie. line) that can be read,
+// which defaults to bufio.MaxScanTokenSize (as does any n <= 0).
+func (Opt) MaxTokenSize(n int) Option {
+	return func(c *config) {
+		if n <= 0 {
+			n = bufio.MaxScanTokenSize
+		}
+		c.maxTokenSize = n
+	}
+}
+
+func scan[V any](reader io.Reader, split bufio.SplitFunc, convert func([]byte) V, options []Option) iter.Seq2[V, error] {
+	config := config{maxTokenSize: bufio.MaxScanTokenSize}
+	for _, option := range options {
+		option(&config)
+	}
+	return func(yield func(V, error) bool) {
+		source, err := decompress(reader)
+		if err != nil {
+			var zero V
+			yield(zero, err)
+			return
+		}
+		defer func() { _ = source.Close() }()
+		scanner := bufio.NewScanner(source)
+		scanner.Buffer(make([]byte, 0, min(4096, config.maxTokenSize)), config.maxTokenSize)
+		scanner.Split(split)
+		for scanner.Scan() {
+			if !yield(convert(scanner.Bytes()), nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			var zero V
+			yield(zero, err)
+		}
+	}
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompress returns a gzip reader over reader if its content starts with the gzip
+// magic number, or (a buffered view of) reader itself otherwise.
+func decompress(reader io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(reader)
+	magic, _ := buffered.Peek(len(gzipMagic))
+	if !bytes.Equal(magic, gzipMagic) {
+		return io.NopCloser(buffered), nil
+	}
+	return gzip.NewReader(buffered)
+}
+
+func scanSeparated(sep []byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if i := bytes.Index(data, sep); i >= 0 {
+			return i + len(sep), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
+
+func bytesToString(token []byte) string { return string(token) }
@@ -0,0 +1,99 @@
+package text_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/text"
+	"github.com/mdw-go/funcy/ranger/try"
+)
+
+const poem = "two roads diverged\r\nin a  yellow wood\n\nand sorry I could not\n"
+
+func TestLines(t *testing.T) {
+	lines, err := try.CollectErr(text.Lines(strings.NewReader(poem)))
+	should.So(t, lines, should.Equal, []string{"two roads diverged", "in a  yellow wood", "", "and sorry I could not"})
+	should.So(t, err, should.BeNil)
+
+	lines, err = try.CollectErr(text.Lines(strings.NewReader("")))
+	should.So(t, lines, should.BeEmpty)
+	should.So(t, err, should.BeNil)
+
+	first := ranger.Slice(ranger.Take(1, try.Values(text.Lines(strings.NewReader(poem)))))
+	should.So(t, first, should.Equal, []string{"two roads diverged"})
+}
+func TestWords(t *testing.T) {
+	words, err := try.CollectErr(text.Words(strings.NewReader(poem)))
+	should.So(t, words, should.Equal, strings.Fields(poem))
+	should.So(t, err, should.BeNil)
+}
+func TestFields(t *testing.T) {
+	fields, err := try.CollectErr(text.Fields(", ", strings.NewReader("a, b,c, , d, ")))
+	should.So(t, fields, should.Equal, []string{"a", "b,c", "", "d"})
+	should.So(t, err, should.BeNil)
+
+	fields, err = try.CollectErr(text.Fields("", strings.NewReader("héllo")))
+	should.So(t, fields, should.Equal, []string{"h", "é", "l", "l", "o"})
+	should.So(t, err, should.BeNil)
+}
+func TestRunes(t *testing.T) {
+	runes, err := try.CollectErr(text.Runes(strings.NewReader("añb\xff")))
+	should.So(t, runes, should.Equal, []rune{'a', 'ñ', 'b', '�'})
+	should.So(t, err, should.BeNil)
+}
+func TestBytes(t *testing.T) {
+	all, err := try.CollectErr(text.Bytes(strings.NewReader("añ")))
+	should.So(t, all, should.Equal, []byte("añ"))
+	should.So(t, err, should.BeNil)
+}
+func TestGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write([]byte(poem))
+	_ = writer.Close()
+
+	words, err := try.CollectErr(text.Words(&compressed))
+	should.So(t, words, should.Equal, strings.Fields(poem))
+	should.So(t, err, should.BeNil)
+
+	all, err := try.CollectErr(text.Bytes(bytes.NewReader([]byte{0x1f})))
+	should.So(t, all, should.Equal, []byte{0x1f})
+	should.So(t, err, should.BeNil)
+
+	_, err = try.CollectErr(text.Lines(bytes.NewReader([]byte{0x1f, 0x8b, 0, 0})))
+	should.So(t, err, should.NOT.BeNil)
+}
+func TestMaxTokenSize(t *testing.T) {
+	lines, err := try.CollectErr(text.Lines(strings.NewReader("short\nmuch too long\nok"), text.Options.MaxTokenSize(8)))
+	should.So(t, lines, should.Equal, []string{"short"})
+	should.So(t, errors.Is(err, bufio.ErrTooLong), should.BeTrue)
+
+	long := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	lines, err = try.CollectErr(text.Lines(strings.NewReader(long), text.Options.MaxTokenSize(2*bufio.MaxScanTokenSize)))
+	should.So(t, lines, should.Equal, []string{long})
+	should.So(t, err, should.BeNil)
+
+	for _, n := range []int{0, -1} {
+		lines, err = try.CollectErr(text.Lines(strings.NewReader("a\nb"), text.Options.MaxTokenSize(n)))
+		should.So(t, lines, should.Equal, []string{"a", "b"})
+		should.So(t, err, should.BeNil)
+	}
+}
+func TestReadError(t *testing.T) {
+	failure := errors.New("failure")
+	lines, err := try.CollectErr(text.Lines(io.MultiReader(strings.NewReader("a\nb\nc"), iotest.ErrReader(failure))))
+	should.So(t, lines, should.Equal, []string{"a", "b", "c"})
+	should.So(t, err, should.Equal, failure)
+
+	lines, err = try.CollectErr(text.Lines(iotest.ErrReader(failure)))
+	should.So(t, lines, should.BeEmpty)
+	should.So(t, err, should.Equal, failure)
+}
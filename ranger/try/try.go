@@ -0,0 +1,138 @@
+// Package try provides combinators over fallible sequences (iter.Seq2[V, error]).
+// Errors flow through each stage alongside values until consumed by TakeUntilErr,
+// CollectErr, or CollectAllErr.
+package try
+
+import (
+	"errors"
+	"iter"
+)
+
+func CollectAllErr[V any](seq iter.Seq2[V, error]) (result []V, err error) {
+	var errs []error
+	for v, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, errors.Join(errs...)
+}
+func CollectErr[V any](seq iter.Seq2[V, error]) (result []V, err error) {
+	for v, err := range seq {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+func Filter[V any](predicate func(V) bool, seq iter.Seq2[V, error]) iter.Seq2[V, error] {
+	return FilterErr(Func(predicate), seq)
+}
+func FilterErr[V any](predicate func(V) (bool, error), seq iter.Seq2[V, error]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for v, err := range seq {
+			if err != nil {
+				if !yield(v, err) {
+					return
+				}
+				continue
+			}
+			keep, err := predicate(v)
+			if err != nil {
+				var zero V
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if keep && !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+func From[V any](seq iter.Seq[V]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+func Func[I, O any](f func(I) O) func(I) (O, error) {
+	return func(i I) (O, error) { return f(i), nil }
+}
+
+// Lift adapts a plain ranger stage (e.g. a partial application of ranger.Take) to a fallible
+// sequence; since the stage cannot see errors, iteration ends at the first one, which is yielded last.
+func Lift[I, O any](stage func(iter.Seq[I]) iter.Seq[O]) func(iter.Seq2[I, error]) iter.Seq2[O, error] {
+	return func(seq iter.Seq2[I, error]) iter.Seq2[O, error] {
+		return func(yield func(O, error) bool) {
+			var err error
+			values := func(yield func(I) bool) {
+				for v, e := range seq {
+					if e != nil {
+						err = e
+						return
+					}
+					if !yield(v) {
+						return
+					}
+				}
+			}
+			for o := range stage(values) {
+				if !yield(o, nil) {
+					return
+				}
+			}
+			if err != nil {
+				var zero O
+				yield(zero, err)
+			}
+		}
+	}
+}
+func Map[I, O any](f func(I) O, seq iter.Seq2[I, error]) iter.Seq2[O, error] {
+	return MapErr(Func(f), seq)
+}
+func MapErr[I, O any](f func(I) (O, error), seq iter.Seq2[I, error]) iter.Seq2[O, error] {
+	return func(yield func(O, error) bool) {
+		for v, err := range seq {
+			if err != nil {
+				var zero O
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+func TakeUntilErr[V any](seq iter.Seq2[V, error]) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for v, err := range seq {
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+func Values[V any](seq iter.Seq2[V, error]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v, err := range seq {
+			if err != nil {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
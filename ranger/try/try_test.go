@@ -0,0 +1,84 @@
+package try_test
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/is"
+	"github.com/mdw-go/funcy/ranger/try"
+)
+
+func parse(s ...string) iter.Seq2[int, error] {
+	return try.MapErr(strconv.Atoi, try.From(ranger.Iterator(s)))
+}
+func TestCollectErr(t *testing.T) {
+	values, err := try.CollectErr(parse("1", "2", "3"))
+	should.So(t, values, should.Equal, []int{1, 2, 3})
+	should.So(t, err, should.BeNil)
+
+	values, err = try.CollectErr(parse("1", "a", "3", "b"))
+	should.So(t, values, should.Equal, []int{1})
+	should.So(t, errors.Is(err, strconv.ErrSyntax), should.BeTrue)
+}
+func TestCollectAllErr(t *testing.T) {
+	values, err := try.CollectAllErr(parse("1", "a", "3", "b"))
+	should.So(t, values, should.Equal, []int{1, 3})
+	should.So(t, err.Error(), should.Equal,
+		"strconv.Atoi: parsing \"a\": invalid syntax\n"+
+			"strconv.Atoi: parsing \"b\": invalid syntax")
+}
+func TestFilter(t *testing.T) {
+	values, err := try.CollectAllErr(try.Filter(is.Even[int], parse("1", "2", "x", "4")))
+	should.So(t, values, should.Equal, []int{2, 4})
+	should.So(t, errors.Is(err, strconv.ErrSyntax), should.BeTrue)
+
+	boom := errors.New("boom")
+	failOn3 := func(n int) (bool, error) {
+		if n == 3 {
+			return false, boom
+		}
+		return true, nil
+	}
+	values, err = try.CollectErr(try.FilterErr(failOn3, parse("1", "2", "3", "4")))
+	should.So(t, values, should.Equal, []int{1, 2})
+	should.So(t, err, should.Equal, boom)
+	for v, err := range try.FilterErr(failOn3, parse("3")) {
+		should.So(t, v, should.Equal, 0)
+		should.So(t, err, should.Equal, boom)
+	}
+}
+func TestMap(t *testing.T) {
+	values, err := try.CollectAllErr(try.Map(strconv.Itoa, parse("1", "x", "3")))
+	should.So(t, values, should.Equal, []string{"1", "3"})
+	should.So(t, errors.Is(err, strconv.ErrSyntax), should.BeTrue)
+}
+func TestTakeUntilErr(t *testing.T) {
+	var values []int
+	var errs []error
+	for v, err := range try.TakeUntilErr(parse("1", "2", "x", "4")) {
+		values = append(values, v)
+		errs = append(errs, err)
+	}
+	should.So(t, values, should.Equal, []int{1, 2, 0})
+	should.So(t, errs[:2], should.Equal, []error{nil, nil})
+	should.So(t, errors.Is(errs[2], strconv.ErrSyntax), should.BeTrue)
+}
+func TestValues(t *testing.T) {
+	should.So(t, ranger.Slice(try.Values(parse("1", "x", "3", "4"))), should.Equal, []int{1, 3, 4})
+	should.So(t, ranger.Slice(ranger.Take(1, try.Values(parse("1", "x", "3")))), should.Equal, []int{1})
+}
+func TestLift(t *testing.T) {
+	take2 := try.Lift(func(s iter.Seq[int]) iter.Seq[int] { return ranger.Take(2, s) })
+	values, err := try.CollectErr(take2(parse("1", "2", "3", "x")))
+	should.So(t, values, should.Equal, []int{1, 2})
+	should.So(t, err, should.BeNil)
+
+	squares := try.Lift(func(s iter.Seq[int]) iter.Seq[int] { return ranger.Map(func(n int) int { return n * n }, s) })
+	values, err = try.CollectErr(squares(parse("2", "3", "x", "4")))
+	should.So(t, values, should.Equal, []int{4, 9})
+	should.So(t, errors.Is(err, strconv.ErrSyntax), should.BeTrue)
+}
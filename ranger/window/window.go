@@ -0,0 +1,180 @@
+// Package window computes aggregates over a sliding window of the most recent n values
+// of a sequence. Results begin once the first window is full, so an input of length L
+// yields L-n+1 results (and none at all when n <= 0 or L < n). EWMA is the exception,
+// as it is defined over all values seen so far and yields one result per input value.
+package window
+
+import (
+	"iter"
+	"math"
+
+	"github.com/mdw-go/funcy/ranger/is"
+	"github.com/mdw-go/funcy/ranger/ring"
+)
+
+// EWMA yields the exponentially weighted moving average of seq, seeded with the first
+// value and then computed as alpha*value + (1-alpha)*previous for each value thereafter.
+func EWMA[N is.Number](alpha float64, seq iter.Seq[N]) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		average, seeded := 0.0, false
+		for n := range seq {
+			if seeded {
+				average = alpha*float64(n) + (1-alpha)*average
+			} else {
+				average, seeded = float64(n), true
+			}
+			if !yield(average) {
+				return
+			}
+		}
+	}
+}
+
+// Rolling yields agg applied to each full window (iterated oldest to newest). The
+// window is only valid during the call to agg.
+func Rolling[V, O any](n int, agg func(window iter.Seq[V]) O, seq iter.Seq[V]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		if n <= 0 {
+			return
+		}
+		buffer := ring.New[V](n, ring.Overwrite)
+		for v := range seq {
+			buffer.Push(v)
+			if buffer.Full() && !yield(agg(buffer.All())) {
+				return
+			}
+		}
+	}
+}
+func RollingMax[N is.Comparable](n int, seq iter.Seq[N]) iter.Seq[N] {
+	return extreme(n, is.LessThan[N], seq)
+}
+func RollingMean[N is.Number](n int, seq iter.Seq[N]) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for sum := range rollingSum(n, seq, func(v N) float64 { return float64(v) }) {
+			if !yield(sum / float64(n)) {
+				return
+			}
+		}
+	}
+}
+func RollingMin[N is.Comparable](n int, seq iter.Seq[N]) iter.Seq[N] {
+	return extreme(n, is.GreaterThan[N], seq)
+}
+
+// RollingSum maintains a running total, adding each arriving value and subtracting the
+// value leaving the window, so each result costs O(1) regardless of n. Non-finite values
+// (NaN, ±Inf) are counted rather than added, so they affect only the windows holding them.
+func RollingSum[N is.Number](n int, seq iter.Seq[N]) iter.Seq[N] {
+	return rollingSum(n, seq, func(v N) N { return v })
+}
+
+// rollingSum implements RollingSum, totalling in T (as converted from N) so that
+// RollingMean can total in float64 rather than overflow a narrow integer type.
+func rollingSum[N, T is.Number](n int, seq iter.Seq[N], convert func(N) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		buffer := ring.New[T](n, ring.Overwrite)
+		var sum runningSum[T]
+		for v := range seq {
+			if buffer.Full() {
+				oldest, _ := buffer.Pop()
+				sum.add(oldest, -1)
+			}
+			value := convert(v)
+			buffer.Push(value)
+			sum.add(value, 1)
+			if buffer.Full() && !yield(sum.value()) {
+				return
+			}
+		}
+	}
+}
+
+// runningSum totals finite values and counts non-finite ones, since subtracting
+// an infinity (or NaN) that leaves the window cannot restore a finite total. The
+// finite total uses Neumaier's compensated summation, keeping the low-order bits
+// lost to rounding in compensation, so that small values added while a large one
+// was in the window survive its departure. (For integers the compensation stays 0.)
+type runningSum[N is.Number] struct {
+	finite       N
+	compensation N
+	nans         int
+	posInfs      int
+	negInfs      int
+}
+
+// add adds (sign 1) or subtracts (sign -1) v.
+func (this *runningSum[N]) add(v N, sign int) {
+	switch f := float64(v); {
+	case math.IsNaN(f):
+		this.nans += sign
+	case math.IsInf(f, 1):
+		this.posInfs += sign
+	case math.IsInf(f, -1):
+		this.negInfs += sign
+	case sign > 0:
+		this.accumulate(v)
+	default:
+		this.accumulate(-v)
+	}
+}
+func (this *runningSum[N]) accumulate(v N) {
+	total := this.finite + v
+	if magnitude(this.finite) >= magnitude(v) {
+		this.compensation += (this.finite - total) + v
+	} else {
+		this.compensation += (v - total) + this.finite
+	}
+	this.finite = total
+}
+func (this *runningSum[N]) value() N {
+	switch {
+	case this.nans > 0 || (this.posInfs > 0 && this.negInfs > 0):
+		return N(math.NaN())
+	case this.posInfs > 0:
+		return N(math.Inf(1))
+	case this.negInfs > 0:
+		return N(math.Inf(-1))
+	default:
+		return this.finite + this.compensation
+	}
+}
+func magnitude[N is.Number](v N) N {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// extreme maintains a monotonic deque of candidates (oldest first) such that no candidate
+// is dominated by a newer one, yielding the front (the extreme value) of each full window
+// in O(1) amortized time per value. dominated(a, b) reports whether a is beaten by b.
+func extreme[N is.Comparable](n int, dominated func(a, b N) bool, seq iter.Seq[N]) iter.Seq[N] {
+	type candidate struct {
+		index int
+		value N
+	}
+	return func(yield func(N) bool) {
+		if n <= 0 {
+			return
+		}
+		var deque []candidate
+		index := 0
+		for v := range seq {
+			for len(deque) > 0 && !dominated(v, deque[len(deque)-1].value) {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, candidate{index: index, value: v})
+			if deque[0].index <= index-n {
+				deque = deque[1:]
+			}
+			index++
+			if index >= n && !yield(deque[0].value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package window_test
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/window"
+)
+
+var readings = ranger.Variadic(4, 2, 12, 3, 8, 8, 1, 5)
+
+func TestRollingSum(t *testing.T) {
+	should.So(t, ranger.Slice(window.RollingSum(3, readings)), should.Equal, []int{18, 17, 23, 19, 17, 14})
+	should.So(t, ranger.Slice(window.RollingSum(1, readings)), should.Equal, ranger.Slice(readings))
+	should.So(t, ranger.Slice(window.RollingSum(9, readings)), should.BeEmpty)
+	should.So(t, ranger.Slice(window.RollingSum(0, readings)), should.BeEmpty)
+	should.So(t, ranger.Slice(ranger.Take(2, window.RollingSum(2, ranger.RangeOpen(0, 1)))), should.Equal, []int{1, 3})
+	should.So(t, ranger.Slice(window.RollingSum(2, ranger.Variadic(1e17, 1.0, 1.0, 1.0))), should.Equal, []float64{1e17, 2, 2})
+}
+func TestRollingSumNonFinite(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	should.So(t, ranger.Slice(window.RollingSum(2, ranger.Variadic(1, inf, 2, 3, 4))), should.Equal, []float64{inf, inf, 5, 7})
+	should.So(t, ranger.Slice(window.RollingSum(2, ranger.Variadic(-inf, 1, 2))), should.Equal, []float64{-inf, 3})
+	sums := ranger.Slice(window.RollingSum(2, ranger.Variadic(inf, -inf, 1, nan, 2, 3)))
+	should.So(t, len(sums), should.Equal, 5)
+	should.So(t, math.IsNaN(sums[0]), should.BeTrue)
+	should.So(t, sums[1], should.Equal, -inf)
+	should.So(t, math.IsNaN(sums[2]), should.BeTrue)
+	should.So(t, math.IsNaN(sums[3]), should.BeTrue)
+	should.So(t, sums[4], should.Equal, 5.0)
+}
+func TestRollingMean(t *testing.T) {
+	should.So(t, ranger.Slice(window.RollingMean(2, readings)), should.Equal, []float64{3, 7, 7.5, 5.5, 8, 4.5, 3})
+	should.So(t, ranger.Slice(window.RollingMean(-1, readings)), should.BeEmpty)
+
+	means := ranger.Slice(window.RollingMean(2, ranger.Variadic(1, math.NaN(), 2, 3, 4)))
+	should.So(t, len(means), should.Equal, 4)
+	should.So(t, math.IsNaN(means[0]), should.BeTrue)
+	should.So(t, math.IsNaN(means[1]), should.BeTrue)
+	should.So(t, means[2:], should.Equal, []float64{2.5, 3.5})
+	should.So(t, ranger.Slice(window.RollingMean(2, ranger.Variadic[int8](100, 100, 100))), should.Equal, []float64{100, 100})
+}
+func TestRollingMinMax(t *testing.T) {
+	should.So(t, ranger.Slice(window.RollingMax(3, readings)), should.Equal, []int{12, 12, 12, 8, 8, 8})
+	should.So(t, ranger.Slice(window.RollingMin(3, readings)), should.Equal, []int{2, 2, 3, 3, 1, 1})
+	should.So(t, ranger.Slice(window.RollingMax(0, readings)), should.BeEmpty)
+	should.So(t, ranger.Slice(ranger.Take(1, window.RollingMin(2, ranger.RangeOpen(0, 1)))), should.Equal, []int{0})
+
+	random := ranger.Slice(ranger.Take(500, ranger.Repeatedly(func() int { return rand.N(100) })))
+	for _, n := range []int{1, 2, 7, 50} {
+		naive := func(agg func(iter.Seq[int]) int) []int {
+			return ranger.Slice(ranger.Map(agg, ranger.Partition(n, 1, ranger.Iterator(random))))
+		}
+		should.So(t, ranger.Slice(window.RollingMax(n, ranger.Iterator(random))), should.Equal, naive(ranger.Max[int]))
+		should.So(t, ranger.Slice(window.RollingMin(n, ranger.Iterator(random))), should.Equal, naive(ranger.Min[int]))
+		should.So(t, ranger.Slice(window.RollingSum(n, ranger.Iterator(random))), should.Equal, naive(ranger.Sum[int]))
+	}
+}
+func TestEWMA(t *testing.T) {
+	should.So(t, ranger.Slice(window.EWMA(0.5, ranger.Variadic(10, 20, 20, 0))), should.Equal, []float64{10, 15, 17.5, 8.75})
+	should.So(t, ranger.Slice(window.EWMA(1, ranger.Variadic(10, 20))), should.Equal, []float64{10, 20})
+	should.So(t, ranger.Slice(window.EWMA(0.5, ranger.Variadic[int]())), should.BeEmpty)
+	should.So(t, ranger.Slice(ranger.Take(2, window.EWMA(0.5, ranger.RangeOpen(0, 2)))), should.Equal, []float64{0, 1})
+}
+func TestRolling(t *testing.T) {
+	should.So(t, ranger.Slice(window.Rolling(3, ranger.Slice[int], readings)), should.Equal, [][]int{
+		{4, 2, 12}, {2, 12, 3}, {12, 3, 8}, {3, 8, 8}, {8, 8, 1}, {8, 1, 5},
+	})
+	should.So(t, ranger.Slice(window.Rolling(2, ranger.Last[int], readings)), should.Equal, ranger.Slice(ranger.Rest(readings)))
+	should.So(t, ranger.Slice(window.Rolling(0, ranger.Count[int], readings)), should.BeEmpty)
+	should.So(t, ranger.Slice(ranger.Take(2, window.Rolling(2, ranger.Sum[int], ranger.RangeOpen(0, 1)))), should.Equal, []int{1, 3})
+}
@@ -0,0 +1,250 @@
+// Package xf implements Clojure-style transducers: transformations expressed against
+// a Reducer rather than a particular source, so the same recipe can be applied to an
+// iter.Seq (Transduce, Into, Sequence), a slice (Slice), a channel (Chan) or any
+// push-based callback (Push).
+package xf
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// Reducer receives values via Step, which returns false once no more values are wanted
+// (just like yield). Flush is called exactly once after the final Step, giving stateful
+// transducers a chance to emit what they have buffered; it is called even after an early
+// stop, but Step is never called again once it (or a downstream Step) has returned false.
+type Reducer[V any] struct {
+	Step  func(V) bool
+	Flush func()
+}
+
+// Transducer transforms a Reducer of O into a Reducer of I.
+type Transducer[I, O any] func(Reducer[O]) Reducer[I]
+
+func Chain[V any](all ...Transducer[V, V]) Transducer[V, V] {
+	return func(next Reducer[V]) Reducer[V] {
+		for x := len(all) - 1; x >= 0; x-- {
+			next = all[x](next)
+		}
+		return next
+	}
+}
+func Chan[I, O any](ctx context.Context, xform Transducer[I, O], in <-chan I, buffer int) <-chan O {
+	out := make(chan O, max(buffer, 0))
+	go func() {
+		defer close(out)
+		r := xform(sink(func(o O) bool {
+			select {
+			case out <- o:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}))
+		defer r.Flush()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok || !r.Step(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+func Comp[A, B, C any](first Transducer[A, B], second Transducer[B, C]) Transducer[A, C] {
+	return func(next Reducer[C]) Reducer[A] { return first(second(next)) }
+}
+func Dedupe[V comparable]() Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		var previous V
+		first := true
+		return func(v V) bool {
+			if !first && v == previous {
+				return true
+			}
+			first, previous = false, v
+			return next.Step(v)
+		}
+	})
+}
+func Distinct[V comparable]() Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		seen := make(map[V]struct{})
+		return func(v V) bool {
+			if _, ok := seen[v]; ok {
+				return true
+			}
+			seen[v] = struct{}{}
+			return next.Step(v)
+		}
+	})
+}
+func Drop[V any](n int) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		count := 0
+		return func(v V) bool {
+			if count < n {
+				count++
+				return true
+			}
+			return next.Step(v)
+		}
+	})
+}
+func DropWhile[V any](predicate func(V) bool) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		dropping := true
+		return func(v V) bool {
+			if dropping && predicate(v) {
+				return true
+			}
+			dropping = false
+			return next.Step(v)
+		}
+	})
+}
+func Filter[V any](predicate func(V) bool) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		return func(v V) bool {
+			return !predicate(v) || next.Step(v)
+		}
+	})
+}
+func Interpose[V any](sep V) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		first := true
+		return func(v V) bool {
+			if !first && !next.Step(sep) {
+				return false
+			}
+			first = false
+			return next.Step(v)
+		}
+	})
+}
+func Into[I, O any](xform Transducer[I, O], dst []O, seq iter.Seq[I]) []O {
+	return Transduce(xform, func(dst []O, o O) []O { return append(dst, o) }, dst, seq)
+}
+func Map[I, O any](f func(I) O) Transducer[I, O] {
+	return withStep(func(next Reducer[O]) func(I) bool {
+		return func(i I) bool { return next.Step(f(i)) }
+	})
+}
+func MapCat[I, O any](f func(I) iter.Seq[O]) Transducer[I, O] {
+	return withStep(func(next Reducer[O]) func(I) bool {
+		return func(i I) bool {
+			for o := range f(i) {
+				if !next.Step(o) {
+					return false
+				}
+			}
+			return true
+		}
+	})
+}
+func Partition[V any](n int) Transducer[V, []V] {
+	return partition[V](n, false)
+}
+func PartitionAll[V any](n int) Transducer[V, []V] {
+	return partition[V](n, true)
+}
+func Push[I, O any](xform Transducer[I, O], f func(O) bool) Reducer[I] {
+	return xform(sink(f))
+}
+func Remove[V any](predicate func(V) bool) Transducer[V, V] {
+	return Filter(func(v V) bool { return !predicate(v) })
+}
+func Sequence[I, O any](xform Transducer[I, O], seq iter.Seq[I]) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		run(xform(sink(yield)), seq)
+	}
+}
+func Slice[I, O any](xform Transducer[I, O], src []I) []O {
+	return Into(xform, []O(nil), slices.Values(src))
+}
+func Take[V any](n int) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		count := 0
+		return func(v V) bool {
+			if count >= n {
+				return false
+			}
+			count++
+			return next.Step(v) && count < n
+		}
+	})
+}
+func TakeWhile[V any](predicate func(V) bool) Transducer[V, V] {
+	return withStep(func(next Reducer[V]) func(V) bool {
+		return func(v V) bool {
+			return predicate(v) && next.Step(v)
+		}
+	})
+}
+func Transduce[I, O, A any](xform Transducer[I, O], f func(A, O) A, init A, seq iter.Seq[I]) A {
+	result := init
+	run(xform(sink(func(o O) bool { result = f(result, o); return true })), seq)
+	return result
+}
+
+func partition[V any](n int, partial bool) Transducer[V, []V] {
+	return func(next Reducer[[]V]) Reducer[V] {
+		buffer := make([]V, 0, max(n, 0))
+		stopped := false
+		return Reducer[V]{
+			Step: func(v V) bool {
+				if n <= 0 {
+					return false
+				}
+				buffer = append(buffer, v)
+				if len(buffer) < n {
+					return true
+				}
+				chunk := slices.Clone(buffer)
+				buffer = buffer[:0]
+				stopped = !next.Step(chunk)
+				return !stopped
+			},
+			Flush: func() {
+				if partial && !stopped && len(buffer) > 0 {
+					next.Step(slices.Clone(buffer))
+				}
+				next.Flush()
+			},
+		}
+	}
+}
+func run[V any](r Reducer[V], seq iter.Seq[V]) {
+	defer r.Flush()
+	for v := range seq {
+		if !r.Step(v) {
+			return
+		}
+	}
+}
+
+// sink terminates a chain of transducers with f, guarding it from being
+// called again once it has returned false.
+func sink[V any](f func(V) bool) Reducer[V] {
+	stopped := false
+	return Reducer[V]{
+		Step: func(v V) bool {
+			stopped = stopped || !f(v)
+			return !stopped
+		},
+		Flush: func() {},
+	}
+}
+
+// withStep builds a Transducer from a step constructor, which is invoked once per
+// application so that any state it closes over is never shared between reductions.
+func withStep[I, O any](step func(next Reducer[O]) func(I) bool) Transducer[I, O] {
+	return func(next Reducer[O]) Reducer[I] {
+		return Reducer[I]{Step: step(next), Flush: next.Flush}
+	}
+}
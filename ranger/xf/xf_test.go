@@ -0,0 +1,92 @@
+package xf_test
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mdw-go/funcy/ranger"
+	"github.com/mdw-go/funcy/ranger/internal/should"
+	"github.com/mdw-go/funcy/ranger/is"
+	"github.com/mdw-go/funcy/ranger/op"
+	"github.com/mdw-go/funcy/ranger/xf"
+)
+
+func TestTransducers(t *testing.T) {
+	should.So(t, xf.Slice(xf.Map(strconv.Itoa), []int{1, 2}), should.Equal, []string{"1", "2"})
+	should.So(t, xf.Slice(xf.Filter(is.Even[int]), []int{1, 2, 3, 4}), should.Equal, []int{2, 4})
+	should.So(t, xf.Slice(xf.Remove(is.Even[int]), []int{1, 2, 3, 4}), should.Equal, []int{1, 3})
+	should.So(t, xf.Slice(xf.Take[int](2), []int{1, 2, 3, 4}), should.Equal, []int{1, 2})
+	should.So(t, xf.Slice(xf.Take[int](0), []int{1, 2, 3, 4}), should.Equal, []int(nil))
+	should.So(t, xf.Slice(xf.Drop[int](2), []int{1, 2, 3, 4}), should.Equal, []int{3, 4})
+	should.So(t, xf.Slice(xf.TakeWhile(is.Odd[int]), []int{1, 3, 4, 5}), should.Equal, []int{1, 3})
+	should.So(t, xf.Slice(xf.DropWhile(is.Odd[int]), []int{1, 3, 4, 5}), should.Equal, []int{4, 5})
+	should.So(t, xf.Slice(xf.Dedupe[int](), []int{1, 1, 2, 1, 1}), should.Equal, []int{1, 2, 1})
+	should.So(t, xf.Slice(xf.Distinct[int](), []int{1, 1, 2, 1, 3}), should.Equal, []int{1, 2, 3})
+	should.So(t, xf.Slice(xf.Interpose(0), []int{1, 2, 3}), should.Equal, []int{1, 0, 2, 0, 3})
+	should.So(t, xf.Slice(xf.MapCat(func(n int) iter.Seq[int] { return ranger.RepeatN(n, n) }), []int{1, 2, 3}), should.Equal, []int{1, 2, 2, 3, 3, 3})
+	should.So(t, xf.Slice(xf.Partition[int](2), []int{1, 2, 3, 4, 5}), should.Equal, [][]int{{1, 2}, {3, 4}})
+	should.So(t, xf.Slice(xf.PartitionAll[int](2), []int{1, 2, 3, 4, 5}), should.Equal, [][]int{{1, 2}, {3, 4}, {5}})
+	should.So(t, xf.Slice(xf.Partition[int](0), []int{1, 2, 3}), should.Equal, [][]int(nil))
+}
+func TestComposition(t *testing.T) {
+	recipe := xf.Comp(xf.Chain(xf.Map(op.Square[int]), xf.Filter(is.Even[int]), xf.Take[int](10)), xf.Map(strconv.Itoa))
+	should.So(t, xf.Transduce(recipe, func(a, b string) string { return a + b }, "", ranger.RangeOpen(0, 1)), should.Equal, "04163664100144196256324")
+
+	batches := xf.Comp(xf.Take[int](5), xf.PartitionAll[int](2))
+	should.So(t, xf.Into(batches, nil, ranger.RangeOpen(0, 1)), should.Equal, [][]int{{0, 1}, {2, 3}, {4}})
+	should.So(t, xf.Into(batches, [][]int{{-1}}, ranger.Range(0, 3)), should.Equal, [][]int{{-1}, {0, 1}, {2}})
+
+	limited := xf.Comp(xf.PartitionAll[int](2), xf.Take[[]int](2))
+	should.So(t, xf.Slice(limited, []int{1, 2, 3, 4, 5}), should.Equal, [][]int{{1, 2}, {3, 4}})
+}
+func TestSequence(t *testing.T) {
+	seq := xf.Sequence(xf.Comp(xf.Filter(is.Odd[int]), xf.PartitionAll[int](2)), ranger.RangeOpen(0, 1))
+	should.So(t, ranger.Slice(ranger.Take(2, seq)), should.Equal, [][]int{{1, 3}, {5, 7}})
+	should.So(t, ranger.Slice(xf.Sequence(xf.PartitionAll[int](2), ranger.Range(0, 3))), should.Equal, [][]int{{0, 1}, {2}})
+	should.So(t, ranger.Slice(ranger.Take(1, xf.Sequence(xf.PartitionAll[int](2), ranger.Range(0, 3)))), should.Equal, [][]int{{0, 1}})
+}
+func TestPush(t *testing.T) {
+	var got []int
+	r := xf.Push(xf.Comp(xf.Dedupe[int](), xf.Take[int](3)), func(n int) bool { got = append(got, n); return true })
+	for _, n := range []int{1, 1, 2, 2, 3, 4} {
+		if !r.Step(n) {
+			break
+		}
+	}
+	r.Flush()
+	should.So(t, got, should.Equal, []int{1, 2, 3})
+}
+func TestChan(t *testing.T) {
+	before := runtime.NumGoroutine()
+	out := xf.Chan(context.Background(), xf.PartitionAll[int](2), ranger.ToChan(context.Background(), ranger.Range(0, 5), 0), 0)
+	should.So(t, ranger.Slice(ranger.FromChan(out)), should.Equal, [][]int{{0, 1}, {2, 3}, {4}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := ranger.ToChan(ctx, ranger.RangeOpen(0, 1), 0)
+	squares := xf.Chan(ctx, xf.Map(op.Square[int]), in, 0)
+	should.So(t, ranger.Slice(ranger.Take(3, ranger.FromChan(squares))), should.Equal, []int{0, 1, 4})
+	cancel()
+	for range squares {
+	}
+	for range in {
+	}
+	should.So(t, goroutinesSettle(before), should.BeTrue)
+}
+
+// goroutinesSettle reports whether the number of goroutines drops to (at most) n
+// before a deadline, allowing goroutines that are on their way out (eg. having
+// already closed their output channel) to finish exiting.
+func goroutinesSettle(n int) bool {
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}